@@ -0,0 +1,66 @@
+package palletizer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PackBatch packs independent requests with up to concurrency Pack calls in
+// flight at once, sharing the client's HTTP connections, and returns the
+// responses and errors index-aligned with requests: for each i, either
+// responses[i] or errs[i] is set. A concurrency below 1 packs one request at
+// a time. If ctx is cancelled, calls in flight are aborted and requests not
+// yet started fail with the context's error.
+func (c *Client) PackBatch(ctx context.Context, requests []*PackingRequest, concurrency int) ([]*PackingResponse, []error) {
+	responses := make([]*PackingResponse, len(requests))
+	errs := make([]error, len(requests))
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(requests); j++ {
+				errs[j] = err
+			}
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			responses[i], errs[i] = c.Pack(ctx, request)
+		}()
+	}
+	wg.Wait()
+	return responses, errs
+}
+
+// PackBatchServer sends several packing requests to the server's batch
+// endpoint in a single call and returns one response per request, in the
+// same order. A request that fails on its own is reported through the Error
+// field of its response rather than failing the batch, so the other results
+// are kept; the returned error covers only failures of the call as a whole.
+func (c *Client) PackBatchServer(ctx context.Context, reqs []*PackingRequest) ([]*PackingResponse, error) {
+	batch := make([]*PackingRequest, len(reqs))
+	for i, request := range reqs {
+		batch[i] = c.withDefaults(request)
+	}
+
+	var responses []*PackingResponse
+	if err := c.do(ctx, "POST", "/api/v1/pack/batch", batch, &responses); err != nil {
+		return nil, err
+	}
+	if len(responses) != len(reqs) {
+		return nil, fmt.Errorf("API returned %d responses for %d requests", len(responses), len(reqs))
+	}
+	for i, response := range responses {
+		if response == nil {
+			responses[i] = &PackingResponse{Error: "no response for request"}
+		}
+	}
+	return responses, nil
+}
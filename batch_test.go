@@ -0,0 +1,139 @@
+package palletizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPackBatchServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/pack/batch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var reqs []PackingRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil || len(reqs) != 3 {
+			t.Errorf("expected 3 requests, got %d (%v)", len(reqs), err)
+		}
+		w.Write([]byte(`[
+			{"pallets":[{"pallet_id":1}],"summary":{"total_pallets":1}},
+			{"pallets":[],"error":"carton BIG exceeds pallet dimensions"},
+			{"pallets":[{"pallet_id":1},{"pallet_id":2}],"summary":{"total_pallets":2}}
+		]`))
+	}))
+	defer server.Close()
+
+	reqs := []*PackingRequest{{}, {}, {}}
+	responses, err := NewWithEndpoint(server.URL).PackBatchServer(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("PackBatchServer failed: %v", err)
+	}
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	if len(responses[0].Pallets) != 1 || len(responses[2].Pallets) != 2 {
+		t.Error("expected responses in request order")
+	}
+	if responses[1].Error != "carton BIG exceeds pallet dimensions" {
+		t.Errorf("expected per-request error, got %q", responses[1].Error)
+	}
+}
+
+func TestPackBatchServerLengthMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"pallets":[]}]`))
+	}))
+	defer server.Close()
+
+	_, err := NewWithEndpoint(server.URL).PackBatchServer(context.Background(), []*PackingRequest{{}, {}})
+	if err == nil || err.Error() != "API returned 1 responses for 2 requests" {
+		t.Errorf("expected length mismatch error, got %v", err)
+	}
+}
+
+func TestPackBatch(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var request PackingRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		quantity := request.Cartons[0].Quantity
+		if quantity == 13 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"summary":{"total_cartons_packed":%d}}`, quantity)
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	requests := make([]*PackingRequest, 20)
+	for i := range requests {
+		requests[i] = &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: i + 1}}}
+	}
+	responses, errs := client.PackBatch(context.Background(), requests, 4)
+
+	if len(responses) != len(requests) || len(errs) != len(requests) {
+		t.Fatalf("expected %d results, got %d responses and %d errors", len(requests), len(responses), len(errs))
+	}
+	for i := range requests {
+		if i+1 == 13 {
+			if errs[i] == nil || responses[i] != nil {
+				t.Errorf("request %d: expected an error, got %+v", i, responses[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("request %d: unexpected error %v", i, errs[i])
+		} else if responses[i].Summary.TotalCartonsPacked != i+1 {
+			t.Errorf("request %d: got the response for request %d", i, responses[i].Summary.TotalCartonsPacked-1)
+		}
+	}
+	if p := atomic.LoadInt32(&peak); p > 4 || p < 2 {
+		t.Errorf("expected between 2 and 4 concurrent requests, peaked at %d", p)
+	}
+}
+
+func TestPackBatchCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	client := NewWithEndpoint(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	requests := make([]*PackingRequest, 10)
+	for i := range requests {
+		requests[i] = &PackingRequest{}
+	}
+	responses, errs := client.PackBatch(ctx, requests, 3)
+
+	for i := range requests {
+		if !errors.Is(errs[i], context.Canceled) || responses[i] != nil {
+			t.Errorf("request %d: expected cancellation, got %v", i, errs[i])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package palletizer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// ErrChecksumMismatch is returned by LoadCompressed when a file's CRC32
+// footer does not match its contents, usually because it was truncated or
+// corrupted
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// SaveCompressed writes the response to path as gzipped JSON followed by a
+// 4-byte big-endian CRC32 (IEEE) of the compressed data
+func (r *PackingResponse) SaveCompressed(path string) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(r); err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress response: %w", err)
+	}
+	buf.Write(binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(buf.Bytes())))
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadCompressed reads a response written by SaveCompressed, returning an
+// error wrapping ErrChecksumMismatch if the file fails its integrity check
+func LoadCompressed(path string) (*PackingResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%s: %w: file too short", path, ErrChecksumMismatch)
+	}
+	payload, footer := data[:len(data)-4], data[len(data)-4:]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(footer) {
+		return nil, fmt.Errorf("%s: %w", path, ErrChecksumMismatch)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	defer zr.Close()
+	jsonData, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+
+	var response PackingResponse
+	if err := json.Unmarshal(jsonData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,38 @@
+package palletizer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadCompressed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "response.gz")
+	response := &PackingResponse{
+		Pallets: []Pallet{{PalletID: 1, TotalWeight: 18143.68, Cartons: []PlacedCarton{{CartonID: "BOX001_1"}}}},
+		Summary: PackingSummary{TotalPallets: 1, TotalCartonsPacked: 1},
+	}
+
+	if err := response.SaveCompressed(path); err != nil {
+		t.Fatalf("SaveCompressed failed: %v", err)
+	}
+	loaded, err := LoadCompressed(path)
+	if err != nil {
+		t.Fatalf("LoadCompressed failed: %v", err)
+	}
+	if loaded.Summary.TotalPallets != 1 || loaded.Pallets[0].Cartons[0].CartonID != "BOX001_1" {
+		t.Errorf("unexpected loaded response: %+v", loaded)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data[:len(data)-10], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCompressed(path); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch for truncated file, got %v", err)
+	}
+}
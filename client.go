@@ -31,61 +31,148 @@
 package palletizer
 
 import (
+	"bufio"
 	"bytes"
+	"cmp"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
+// Version is the version of this SDK, sent in the default User-Agent header
+const Version = "0.1.0"
+
 const defaultAPIURL = "https://api.palletizer.app"
 
-// Client is the Palletizer API client
+const defaultUserAgent = "palletizer-go/" + Version
+
+const defaultTimeout = 120 * time.Second
+
+const defaultPollInterval = time.Second
+
+// Client is the Palletizer API client.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its
+// configuration is fixed at construction and never modified by calls, so a
+// single Client should be created once and shared.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL       string
+	httpClient    *http.Client
+	minTLSVersion uint16
+	timeout       time.Duration
+	userAgent     string
+	apiKey        string
+	pollInterval  time.Duration
+	logHooks      []func(context.Context, LogEvent)
+	healthCache   *healthCache
+	beforeRequest []func(context.Context, *http.Request) error
+	afterResponse []func(context.Context, *http.Response, time.Duration) error
+	retry         retryPolicy
+	limiter       *rate.Limiter
+	idGenerator   func() string
+
+	defaultConstraints *PackingConstraints
+	callTimeout        time.Duration // deadline for calls whose context has none
+	methodOverride     string        // header tunneling GET requests through POST, if set
+	routeTag           string        // X-Route-Tag header value, if set
+	logBodies          bool          // whether log events carry request bodies
+	tracer             trace.Tracer  // Pack span tracer, if set
+	offlineFallback    bool          // whether Pack falls back to PackOffline
+	configErr          error         // invalid option values, returned by every call
+}
+
+// NewClient creates a new Palletizer API client configured by opts. Without
+// options it talks to the default endpoint over an HTTP client with a 120
+// second timeout.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:      defaultAPIURL,
+		userAgent:    defaultUserAgent,
+		pollInterval: defaultPollInterval,
+		retry:        defaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	switch {
+	case c.httpClient == nil:
+		c.httpClient = newDefaultHTTPClient(c.minTLSVersion)
+		if c.timeout > 0 {
+			c.httpClient.Timeout = c.timeout
+		}
+	case c.timeout > 0:
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		c.httpClient = &httpClient
+	}
+	return c
 }
 
 // New creates a new Palletizer API client with the default endpoint
 func New() *Client {
-	return &Client{
-		baseURL: defaultAPIURL,
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second,
-		},
-	}
+	return NewClient()
 }
 
-// NewWithEndpoint creates a client with a custom API endpoint
+// NewWithEndpoint creates a client with a custom API endpoint. It is
+// equivalent to NewClient(WithEndpoint(baseURL)).
 func NewWithEndpoint(baseURL string) *Client {
-	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second,
-		},
-	}
+	return NewClient(WithEndpoint(baseURL))
 }
 
-// NewWithHTTPClient creates a client with a custom HTTP client
+// NewWithHTTPClient creates a client with a custom HTTP client. It is
+// equivalent to NewClient(WithHTTPClient(httpClient)).
 func NewWithHTTPClient(httpClient *http.Client) *Client {
-	return &Client{
-		baseURL:    defaultAPIURL,
-		httpClient: httpClient,
+	return NewClient(WithHTTPClient(httpClient))
+}
+
+// newDefaultHTTPClient returns the HTTP client used when none is supplied. It
+// copies http.DefaultTransport with the minimum TLS version raised to
+// minTLSVersion, or TLS 1.2 if it is 0. If the program has replaced
+// http.DefaultTransport with another RoundTripper, that one is used as is,
+// unless a minimum TLS version was requested, which needs a transport of
+// our own.
+func newDefaultHTTPClient(minTLSVersion uint16) *http.Client {
+	client := &http.Client{Timeout: defaultTimeout}
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = transport.Clone()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.MinVersion = cmp.Or(minTLSVersion, tls.VersionTLS12)
+		client.Transport = transport
+	} else if minTLSVersion != 0 {
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{MinVersion: minTLSVersion},
+		}
 	}
+	return client
 }
 
 // Carton represents a carton to be packed
 type Carton struct {
-	ID            string  `json:"id"`
-	Length        float64 `json:"length"`         // millimeters
-	Width         float64 `json:"width"`          // millimeters
-	Height        float64 `json:"height"`         // millimeters
-	Weight        float64 `json:"weight"`         // grams
-	Quantity      int     `json:"quantity"`       // number of identical cartons
-	Fragile       bool    `json:"fragile"`        // whether carton is fragile
-	AllowRotation bool    `json:"allow_rotation"` // whether carton can be rotated
+	ID             string  `json:"id"`
+	Length         float64 `json:"length"`                     // millimeters
+	Width          float64 `json:"width"`                      // millimeters
+	Height         float64 `json:"height"`                     // millimeters
+	Weight         float64 `json:"weight"`                     // grams
+	Quantity       int     `json:"quantity"`                   // number of identical cartons
+	Fragile        bool    `json:"fragile"`                    // whether carton is fragile
+	AllowRotation  bool    `json:"allow_rotation"`             // whether carton can be rotated
+	MaxLoadBearing float64 `json:"max_load_bearing,omitempty"` // grams the carton can bear on top (0 = unlimited)
+	PreferBottom   bool    `json:"prefer_bottom,omitempty"`    // hint to place the carton in low layers
+	Nestable       bool    `json:"nestable,omitempty"`         // whether identical cartons nest inside each other
+	NestedHeight   float64 `json:"nested_height,omitempty"`    // millimeters each nested carton adds to the stack
+	UprightOnly    bool    `json:"upright_only,omitempty"`     // whether rotation must keep the height axis vertical
+	Zone           string  `json:"zone,omitempty"`             // destination zone; cartons sharing a zone are kept together
 }
 
 // PackingConstraints defines the maximum dimensions and weight for a pallet
@@ -98,7 +185,11 @@ type PackingConstraints struct {
 
 // PackingOptions configures the packing algorithm
 type PackingOptions struct {
-	SupportPercentage float64 `json:"support_percentage"` // minimum support area percentage (0-100)
+	SupportPercentage float64 `json:"support_percentage"`           // minimum support area percentage (0-100)
+	TargetUtilization float64 `json:"target_utilization,omitempty"` // utilization percentage the solver should aim for (0-100)
+	Seed              int64   `json:"seed,omitempty"`               // random seed for reproducible solves (0 = non-deterministic; requires server support)
+	MaxIterations     int     `json:"max_iterations,omitempty"`     // upper bound on solver iterations (0 = server default; requires server support)
+	DryRun            bool    `json:"dry_run,omitempty"`            // return only the summary, with no placements (requires server support)
 }
 
 // PackingRequest is the request sent to the Pack API
@@ -122,14 +213,19 @@ type Dimensions struct {
 	Height float64 `json:"height"`
 }
 
+// Orientation names how a placed carton is rotated relative to its
+// original dimensions, as reported by the server (e.g. "original")
+type Orientation string
+
 // PlacedCarton represents a carton placed on a pallet
 type PlacedCarton struct {
-	CartonID    string     `json:"carton_id"`
-	Position    Point3D    `json:"position"`
-	Dimensions  Dimensions `json:"dimensions"`
-	Orientation string     `json:"orientation"`
-	Weight      float64    `json:"weight"`
-	Layer       int        `json:"layer"` // Layer number (0-based)
+	CartonID    string      `json:"carton_id"`
+	Position    Point3D     `json:"position"`
+	Dimensions  Dimensions  `json:"dimensions"`
+	Orientation Orientation `json:"orientation"`
+	Weight      float64     `json:"weight"`
+	Layer       int         `json:"layer"`          // Layer number (0-based)
+	Zone        string      `json:"zone,omitempty"` // destination zone of the carton, echoed by the server
 }
 
 // Pallet represents a packed pallet
@@ -148,13 +244,18 @@ type PackingSummary struct {
 	TotalCartonsPacked int     `json:"total_cartons_packed"`
 	AverageUtilization float64 `json:"average_utilization"`
 	ComputationTimeMs  int     `json:"computation_time_ms"`
+
+	// Timings breaks the computation time down by phase (e.g. "parse",
+	// "solve", "serialize"), in milliseconds, when the server reports it
+	Timings map[string]int `json:"timings,omitempty"`
 }
 
 // PackingResponse is the response from the Pack API
 type PackingResponse struct {
-	Pallets []Pallet       `json:"pallets"`
-	Summary PackingSummary `json:"summary"`
-	Error   string         `json:"error,omitempty"`
+	Pallets  []Pallet       `json:"pallets"`
+	Summary  PackingSummary `json:"summary"`
+	Error    string         `json:"error,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"` // caveats on a successful solve, e.g. relaxed support
 }
 
 // HealthResponse is the response from the Health API
@@ -181,43 +282,358 @@ type MetricsResponse struct {
 	BuildTime      string  `json:"build_time"`
 }
 
-// Pack sends a packing request and returns the packed pallets
+// Pack sends a packing request and returns the packed pallets. With
+// WithOfflineFallback, a request that cannot reach the server is packed by
+// PackOffline instead.
 func (c *Client) Pack(ctx context.Context, request *PackingRequest) (*PackingResponse, error) {
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	response, _, err := c.PackWithResponse(ctx, request)
+	if err != nil && c.offlineFallback && isNetworkError(err) {
+		return PackOffline(c.withDefaults(request))
 	}
+	return response, err
+}
+
+// PackWithWeightCap packs the request with its pallet MaxWeight lowered to
+// capGrams. The request itself is not modified; a cap above the request's
+// MaxWeight has no effect. Default constraints, if set, are applied before
+// the cap.
+func (c *Client) PackWithWeightCap(ctx context.Context, request *PackingRequest, capGrams float64) (*PackingResponse, error) {
+	capped := *c.withDefaults(request)
+	if capped.PackingConstraints.MaxWeight <= 0 || capGrams < capped.PackingConstraints.MaxWeight {
+		capped.PackingConstraints.MaxWeight = capGrams
+	}
+	return c.Pack(ctx, &capped)
+}
+
+// PackResult is the outcome of a Pack call delivered over a channel
+type PackResult struct {
+	Response *PackingResponse
+	Err      error
+}
+
+// PackCancelable starts packing the request in the background and returns a
+// channel that delivers its result, together with a function that aborts the
+// call. The channel receives exactly one PackResult and is then closed; if
+// the call is cancelled first, the result's Err wraps context.Canceled.
+// The cancel function must be called eventually to release resources, and
+// calling it after the result has arrived has no effect.
+func (c *Client) PackCancelable(request *PackingRequest) (<-chan PackResult, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan PackResult, 1)
+	go func() {
+		defer close(results)
+		response, err := c.Pack(ctx, request)
+		results <- PackResult{Response: response, Err: err}
+	}()
+	return results, cancel
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/pack", bytes.NewBuffer(jsonData))
+// ErrMultiplePallets is returned by PackSingle when the request does not fit
+// on one pallet
+type ErrMultiplePallets struct {
+	Count int // number of pallets the solve produced
+}
+
+func (e *ErrMultiplePallets) Error() string {
+	return fmt.Sprintf("request needs %d pallets, expected 1", e.Count)
+}
+
+// PackSingle packs a request that must fit on exactly one pallet and returns
+// that pallet. If the solve produces more than one pallet, the error is an
+// *ErrMultiplePallets carrying the pallet count.
+func (c *Client) PackSingle(ctx context.Context, request *PackingRequest) (*Pallet, error) {
+	response, err := c.Pack(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+	switch len(response.Pallets) {
+	case 0:
+		return nil, errors.New("packing produced no pallets")
+	case 1:
+		return &response.Pallets[0], nil
+	default:
+		return nil, &ErrMultiplePallets{Count: len(response.Pallets)}
 	}
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	resp, err := c.httpClient.Do(req)
+// withDefaults returns the request to send: a copy carrying the client's
+// default constraints if the request leaves them unset, or the request itself
+func (c *Client) withDefaults(request *PackingRequest) *PackingRequest {
+	if c.defaultConstraints == nil || request == nil || request.PackingConstraints != (PackingConstraints{}) {
+		return request
+	}
+	filled := *request
+	filled.PackingConstraints = *c.defaultConstraints
+	return &filled
+}
+
+// withCallTimeout bounds ctx by the client's default call timeout, unless
+// none is configured or ctx already has a deadline
+func (c *Client) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || c.callTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.callTimeout)
+}
+
+// do sends an API request with in encoded as the JSON body (if non-nil) and
+// decodes a successful JSON response into out (if non-nil).
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	_, err := c.doMeta(ctx, method, path, in, out)
+	return err
+}
+
+// doMeta is like do but also returns metadata about the HTTP exchange. The
+// metadata is non-nil even when an error is returned.
+func (c *Client) doMeta(ctx context.Context, method, path string, in, out any) (meta *ResponseMeta, err error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	meta = &ResponseMeta{CorrelationID: c.newCorrelationID()}
+	var header http.Header
+	if meta.CorrelationID != "" {
+		header = http.Header{correlationIDHeader: {meta.CorrelationID}}
+	}
+
+	var respBody []byte
+	var earlier []error
+	for attempt := 1; ; attempt++ {
+		var resp *http.Response
+		resp, respBody, err = c.send(ctx, method, path, in, header)
+		if err == nil {
+			meta.StatusCode = resp.StatusCode
+			meta.Header = resp.Header
+			meta.RequestID = resp.Header.Get(requestIDHeader)
+			if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+				break
+			}
+			err = newAPIError(resp.StatusCode, respBody)
+		}
+		if attempt >= c.retry.maxAttemptsFor(err) || !c.retry.wait(ctx, attempt) {
+			if len(earlier) > 0 {
+				err = &RetryError{Last: err, Earlier: earlier}
+			}
+			return meta, err
+		}
+		earlier = append(earlier, err)
+	}
+
+	if out != nil && meta.StatusCode != http.StatusNoContent {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return meta, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return meta, nil
+}
+
+// APIError is returned when the API responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string // the API's {"error": ...} message, or the raw body if there is none
+	Body       []byte
+}
+
+// newAPIError builds the error for a non-2xx response, preferring the
+// message in the API's {"error": ...} body when present
+func newAPIError(statusCode int, body []byte) *APIError {
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	message := string(body)
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		message = apiErr.Error
+	}
+	return &APIError{StatusCode: statusCode, Message: message, Body: body}
+}
+
+func (e *APIError) Error() string {
+	if e.Message != string(e.Body) {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// send performs a single HTTP attempt, running the before-request and
+// after-response hooks around it, and returns the response with its body
+// fully read.
+func (c *Client) send(ctx context.Context, method, path string, in any, header http.Header) (*http.Response, []byte, error) {
+	start := time.Now()
+	resp, err := c.open(ctx, method, path, in, header)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	elapsed := time.Since(start)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		err = fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(c.logHooks) > 0 {
+		if err == nil && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+			c.logResponseEvent(ctx, resp.Request, path, in, resp.StatusCode, respBody, elapsed, newAPIError(resp.StatusCode, respBody))
+		} else {
+			c.logResponseEvent(ctx, resp.Request, path, in, resp.StatusCode, respBody, elapsed, err)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, hook := range c.afterResponse {
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		if err := hook(ctx, resp, elapsed); err != nil {
+			return nil, nil, fmt.Errorf("after response hook: %w", err)
+		}
+	}
+	return resp, respBody, nil
+}
+
+// open builds the HTTP request with in streamed as the JSON body (if
+// non-nil) and the given per-call headers, runs the before-request hooks and
+// sends it, returning the response with its body unread
+func (c *Client) open(ctx context.Context, method, path string, in any, header http.Header) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit: %w", err)
+		}
 	}
 
-	var response PackingResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	var body io.ReadCloser
+	encodeErr := func() error { return nil }
+	if in != nil {
+		body, encodeErr = encodeJSON(in)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if response.Error != "" {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, response.Error)
+	sendMethod := method
+	if c.methodOverride != "" && method == http.MethodGet {
+		sendMethod = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, sendMethod, c.baseURL+path, body)
+	if err != nil {
+		if body != nil {
+			body.Close()
 		}
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if in != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, _ := encodeJSON(in)
+			return body, nil
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if sendMethod != method {
+		req.Header.Set(c.methodOverride, method)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if c.routeTag != "" {
+		req.Header.Set("X-Route-Tag", c.routeTag)
+	}
+	for key, values := range header {
+		req.Header[key] = values
 	}
 
-	return &response, nil
+	for _, hook := range c.beforeRequest {
+		if err := hook(ctx, req); err != nil {
+			if body != nil {
+				body.Close()
+			}
+			return nil, fmt.Errorf("before request hook: %w", err)
+		}
+	}
+
+	if len(c.logHooks) > 0 {
+		c.logRequestEvent(ctx, req, path, in)
+	}
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if body != nil {
+			body.Close()
+		}
+		if encErr := encodeErr(); encErr != nil {
+			err = fmt.Errorf("failed to marshal request: %w", encErr)
+		} else {
+			err = fmt.Errorf("failed to send request: %w", err)
+		}
+		if len(c.logHooks) > 0 {
+			c.logResponseEvent(ctx, req, path, in, 0, nil, time.Since(start), err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// encodeJSON streams v as JSON through a pipe, so large requests are never
+// held in memory in full. Packing requests are written one carton at a
+// time. The returned function waits for encoding to stop and returns its
+// error; it must be called at most once, after the reader has been consumed
+// or closed, and reports nil if encoding stopped because the reader was
+// closed early.
+func encodeJSON(v any) (io.ReadCloser, func() error) {
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		if req, ok := v.(*PackingRequest); ok && req != nil {
+			err = writeRequestJSON(pw, req)
+		} else {
+			err = json.NewEncoder(pw).Encode(v)
+		}
+		pw.CloseWithError(err)
+		if errors.Is(err, io.ErrClosedPipe) {
+			err = nil
+		}
+		done <- err
+	}()
+	return pr, func() error { return <-done }
+}
+
+// writeRequestJSON writes the same JSON as json.Marshal(req) to w, encoding
+// cartons one at a time so the whole document is never buffered
+func writeRequestJSON(w io.Writer, req *PackingRequest) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`{"cartons":`)
+	if req.Cartons == nil {
+		bw.WriteString("null")
+	} else {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		bw.WriteByte('[')
+		for i := range req.Cartons {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			buf.Reset()
+			if err := enc.Encode(&req.Cartons[i]); err != nil {
+				return err
+			}
+			// Drop Encode's trailing newline. Write errors are sticky, so
+			// this also stops early once the reader is gone.
+			if _, err := bw.Write(buf.Bytes()[:buf.Len()-1]); err != nil {
+				return err
+			}
+		}
+		bw.WriteByte(']')
+	}
+
+	constraints, err := json.Marshal(req.PackingConstraints)
+	if err != nil {
+		return err
+	}
+	options, err := json.Marshal(req.PackingOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(bw, `,"packing_constraints":%s,"packing_options":%s}`, constraints, options)
+	return bw.Flush()
 }
 
 // StandardPallet returns constraints for a standard 40x72x48 inch pallet (1500 lbs)
@@ -240,6 +656,29 @@ func StandardPallet4048() PackingConstraints {
 	}
 }
 
+// EURPallet returns constraints for a EUR1 pallet (EN 13698-1, 1200x800 mm)
+// stacked to 1500 mm with a 1000 kg load. Adjust MaxHeight on the result for
+// other stack heights.
+func EURPallet() PackingConstraints {
+	return PackingConstraints{
+		MaxLength: 1200.0,    // 1200 mm
+		MaxWidth:  800.0,     // 800 mm
+		MaxHeight: 1500.0,    // 1500 mm
+		MaxWeight: 1000000.0, // 1000 kg
+	}
+}
+
+// EURPallet2 returns constraints for a EUR2 pallet (EN 13698-2, 1200x1000
+// mm) stacked to 1500 mm with a 1000 kg load
+func EURPallet2() PackingConstraints {
+	return PackingConstraints{
+		MaxLength: 1200.0,    // 1200 mm
+		MaxWidth:  1000.0,    // 1000 mm
+		MaxHeight: 1500.0,    // 1500 mm
+		MaxWeight: 1000000.0, // 1000 kg
+	}
+}
+
 // InchesToMM converts inches to millimeters
 func InchesToMM(inches float64) float64 {
 	return inches * 25.4
@@ -1,10 +1,18 @@
 package palletizer
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -118,6 +126,383 @@ func TestPack(t *testing.T) {
 	}
 }
 
+func TestPackConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PackingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		response := PackingResponse{
+			Summary: PackingSummary{TotalPallets: 1, TotalCartonsPacked: req.Cartons[0].Quantity},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewWithEndpoint(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(qty int) {
+			defer wg.Done()
+			request := &PackingRequest{
+				Cartons: []Carton{
+					{ID: "BOX001", Length: 100, Width: 100, Height: 100, Weight: 1000, Quantity: qty},
+				},
+				PackingConstraints: StandardPallet(),
+			}
+			response, err := client.Pack(context.Background(), request)
+			if err != nil {
+				t.Errorf("Pack failed: %v", err)
+				return
+			}
+			if response.Summary.TotalCartonsPacked != qty {
+				t.Errorf("expected %d cartons packed, got %d", qty, response.Summary.TotalCartonsPacked)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestPackWithWeightCap(t *testing.T) {
+	var maxWeight float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PackingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		maxWeight = req.PackingConstraints.MaxWeight
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewWithEndpoint(server.URL)
+	request := &PackingRequest{PackingConstraints: StandardPallet()}
+
+	if _, err := client.PackWithWeightCap(context.Background(), request, 450000); err != nil {
+		t.Fatalf("PackWithWeightCap failed: %v", err)
+	}
+	if maxWeight != 450000 {
+		t.Errorf("expected max weight 450000 sent, got %f", maxWeight)
+	}
+	if request.PackingConstraints.MaxWeight != 680388.0 {
+		t.Errorf("expected original request unchanged, got %f", request.PackingConstraints.MaxWeight)
+	}
+
+	if _, err := client.PackWithWeightCap(context.Background(), request, 900000); err != nil {
+		t.Fatalf("PackWithWeightCap failed: %v", err)
+	}
+	if maxWeight != 680388.0 {
+		t.Errorf("expected cap above MaxWeight to be ignored, got %f", maxWeight)
+	}
+}
+
+func TestPackWithWeightCapDefaultConstraints(t *testing.T) {
+	var sent PackingConstraints
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PackingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		sent = req.PackingConstraints
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithDefaultConstraints(EURPallet()))
+	if _, err := client.PackWithWeightCap(context.Background(), &PackingRequest{}, 500000); err != nil {
+		t.Fatalf("PackWithWeightCap failed: %v", err)
+	}
+	expected := EURPallet()
+	expected.MaxWeight = 500000
+	if sent != expected {
+		t.Errorf("expected default constraints with the cap, got %+v", sent)
+	}
+}
+
+func TestPackingOptionsSeed(t *testing.T) {
+	data, err := json.Marshal(PackingOptions{SupportPercentage: 80, Seed: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"support_percentage":80,"seed":42}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	data, err = json.Marshal(PackingOptions{SupportPercentage: 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"support_percentage":80}` {
+		t.Errorf("expected seed to be omitted, got %s", data)
+	}
+}
+
+// checkWireField checks that set marshals field with the raw JSON value want
+// and that unset, its zero-valued counterpart, omits field
+func checkWireField(t *testing.T, set, unset any, field, want string) {
+	t.Helper()
+	fields := func(v any) map[string]json.RawMessage {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	if got := fields(set)[field]; string(got) != want {
+		t.Errorf("expected %s to be %s, got %q", field, want, got)
+	}
+	if got, ok := fields(unset)[field]; ok {
+		t.Errorf("expected %s to be omitted, got %s", field, got)
+	}
+}
+
+func TestCartonMaxLoadBearingJSON(t *testing.T) {
+	checkWireField(t, Carton{ID: "BOX001", MaxLoadBearing: 25000}, Carton{ID: "BOX001"}, "max_load_bearing", "25000")
+}
+
+func TestCartonPreferBottomJSON(t *testing.T) {
+	checkWireField(t, Carton{ID: "BOX001", PreferBottom: true}, Carton{ID: "BOX001"}, "prefer_bottom", "true")
+}
+
+func TestPackingOptionsTargetUtilizationJSON(t *testing.T) {
+	checkWireField(t, PackingOptions{TargetUtilization: 92.5}, PackingOptions{}, "target_utilization", "92.5")
+}
+
+func TestCartonNestingJSON(t *testing.T) {
+	checkWireField(t, Carton{ID: "CUP", Nestable: true}, Carton{ID: "CUP"}, "nestable", "true")
+	checkWireField(t, Carton{ID: "CUP", NestedHeight: 12.5}, Carton{ID: "CUP"}, "nested_height", "12.5")
+}
+
+func TestCartonUprightOnlyJSON(t *testing.T) {
+	checkWireField(t, Carton{ID: "BOX001", UprightOnly: true}, Carton{ID: "BOX001"}, "upright_only", "true")
+}
+
+func TestPackingOptionsMaxIterationsJSON(t *testing.T) {
+	checkWireField(t, PackingOptions{MaxIterations: 5000}, PackingOptions{}, "max_iterations", "5000")
+}
+
+func TestCartonZoneJSON(t *testing.T) {
+	checkWireField(t, Carton{ID: "BOX001", Zone: "A3"}, Carton{ID: "BOX001"}, "zone", `"A3"`)
+	checkWireField(t, PlacedCarton{CartonID: "BOX001_1", Zone: "A3"}, PlacedCarton{CartonID: "BOX001_1"}, "zone", `"A3"`)
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewWithEndpoint(server.URL).Pack(context.Background(), &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if userAgent != "palletizer-go/"+Version {
+		t.Errorf("expected versioned User-Agent, got %q", userAgent)
+	}
+}
+
+func TestPackSingle(t *testing.T) {
+	pallets := `[{"pallet_id":1}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pallets":` + pallets + `}`))
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	pallet, err := client.PackSingle(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("PackSingle failed: %v", err)
+	}
+	if pallet.PalletID != 1 {
+		t.Errorf("expected pallet 1, got %d", pallet.PalletID)
+	}
+
+	pallets = `[{"pallet_id":1},{"pallet_id":2},{"pallet_id":3}]`
+	_, err = client.PackSingle(context.Background(), &PackingRequest{})
+	var multiErr *ErrMultiplePallets
+	if !errors.As(err, &multiErr) || multiErr.Count != 3 {
+		t.Errorf("expected ErrMultiplePallets with count 3, got %v", err)
+	}
+}
+
+func TestPackCancelable(t *testing.T) {
+	var slow atomic.Bool
+	slow.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"pallets":[{"pallet_id":1}]}`))
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	results, cancel := client.PackCancelable(&PackingRequest{})
+	cancel()
+	result := <-results
+	if !errors.Is(result.Err, context.Canceled) || result.Response != nil {
+		t.Errorf("expected cancellation error, got %+v", result)
+	}
+	if _, ok := <-results; ok {
+		t.Error("expected channel to be closed after the result")
+	}
+
+	slow.Store(false)
+	results, cancel = client.PackCancelable(&PackingRequest{})
+	defer cancel()
+	result = <-results
+	if result.Err != nil || len(result.Response.Pallets) != 1 {
+		t.Errorf("expected one pallet, got %+v", result)
+	}
+}
+
+func TestPackEmptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := NewWithEndpoint(server.URL).Pack(context.Background(), &PackingRequest{})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse response") {
+		t.Errorf("expected a parse error for an empty body, got %v", err)
+	}
+}
+
+func TestPackAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewWithEndpoint(server.URL).Pack(context.Background(), &PackingRequest{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Message != "rate limit exceeded" {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+	if string(apiErr.Body) != `{"error":"rate limit exceeded"}` {
+		t.Errorf("unexpected body: %s", apiErr.Body)
+	}
+	if err.Error() != "API error (status 429): rate limit exceeded" {
+		t.Errorf("unexpected message: %v", err)
+	}
+}
+
+func TestPackStreamsRequest(t *testing.T) {
+	var received PackingRequest
+	var contentLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 3}}}
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if contentLength != -1 {
+		t.Errorf("expected a chunked body, got Content-Length %d", contentLength)
+	}
+	if len(received.Cartons) != 1 || received.Cartons[0] != request.Cartons[0] {
+		t.Errorf("unexpected request received: %+v", received)
+	}
+
+	// The server sees a truncated body, so don't assert on what it decodes
+	discard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer discard.Close()
+	request.Cartons[0].Weight = math.NaN()
+	_, err := NewWithEndpoint(discard.URL).Pack(context.Background(), request)
+	if err == nil || !strings.HasPrefix(err.Error(), "failed to marshal request: ") {
+		t.Errorf("expected marshal error, got %v", err)
+	}
+}
+
+func TestWriteRequestJSON(t *testing.T) {
+	for _, request := range []*PackingRequest{
+		{},
+		{Cartons: []Carton{}},
+		{
+			Cartons: []Carton{
+				{ID: "BOX001", Length: 609.6, Width: 457.2, Height: 406.4, Weight: 18143.68, Quantity: 30, AllowRotation: true},
+				{ID: "BOX002", Length: 100, Width: 100, Height: 100, Weight: 500, Quantity: 1, Zone: "A"},
+			},
+			PackingConstraints: StandardPallet(),
+			PackingOptions:     PackingOptions{SupportPercentage: 80, Seed: 7},
+		},
+	} {
+		expected, err := json.Marshal(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := writeRequestJSON(&buf, request); err != nil {
+			t.Fatalf("writeRequestJSON failed: %v", err)
+		}
+		if buf.String() != string(expected) {
+			t.Errorf("expected %s, got %s", expected, buf.String())
+		}
+	}
+}
+
+// BenchmarkPackLargeRequest compares sending a 10,000-carton request
+// marshaled into a single buffer with the streamed body Pack uses.
+func BenchmarkPackLargeRequest(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	request := &PackingRequest{PackingConstraints: StandardPallet()}
+	for i := 0; i < 10000; i++ {
+		request.Cartons = append(request.Cartons, Carton{
+			ID: fmt.Sprintf("SKU%05d", i), Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 1,
+		})
+	}
+
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			payload, err := json.Marshal(request)
+			if err != nil {
+				b.Fatal(err)
+			}
+			resp, err := client.httpClient.Post(server.URL+"/v1/pack", "application/json", bytes.NewBuffer(payload))
+			if err != nil {
+				b.Fatal(err)
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	})
+	b.Run("streamed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := client.Pack(context.Background(), request); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestStandardPallet(t *testing.T) {
 	pallet := StandardPallet()
 	if pallet.MaxLength != 1016.0 {
@@ -134,6 +519,15 @@ func TestStandardPallet(t *testing.T) {
 	}
 }
 
+func TestEURPallets(t *testing.T) {
+	if got, want := EURPallet(), (PackingConstraints{MaxLength: 1200, MaxWidth: 800, MaxHeight: 1500, MaxWeight: 1000000}); got != want {
+		t.Errorf("EURPallet: expected %+v, got %+v", want, got)
+	}
+	if got, want := EURPallet2(), (PackingConstraints{MaxLength: 1200, MaxWidth: 1000, MaxHeight: 1500, MaxWeight: 1000000}); got != want {
+		t.Errorf("EURPallet2: expected %+v, got %+v", want, got)
+	}
+}
+
 func TestConversions(t *testing.T) {
 	tests := []struct {
 		name     string
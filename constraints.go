@@ -0,0 +1,83 @@
+package palletizer
+
+import "math"
+
+// PalletsPerFloor returns how many pallet footprints (MaxLength x MaxWidth)
+// fit on a rectangular floor area (floor.Length x floor.Width, millimeters)
+// when laid out in a simple grid. Both pallet orientations are tried and the
+// better one is used; floor.Height is ignored.
+func PalletsPerFloor(pallet PackingConstraints, floor Dimensions) int {
+	if pallet.MaxLength <= 0 || pallet.MaxWidth <= 0 {
+		return 0
+	}
+	grid := func(l, w float64) int {
+		return int(math.Floor(floor.Length/l)) * int(math.Floor(floor.Width/w))
+	}
+	return max(grid(pallet.MaxLength, pallet.MaxWidth), grid(pallet.MaxWidth, pallet.MaxLength))
+}
+
+// TruckFloorUtilization returns the fraction of a truck's floor
+// (truck.MaxLength x truck.MaxWidth) covered by the footprints of pallets,
+// from 0 to 1. Footprints are summed without checking that the pallets
+// actually fit side by side, so the result is capped at 1.
+func TruckFloorUtilization(pallets []Pallet, truck PackingConstraints) float64 {
+	floor := truck.MaxLength * truck.MaxWidth
+	if floor <= 0 {
+		return 0
+	}
+	var used float64
+	for _, p := range pallets {
+		length, width := p.Footprint()
+		used += length * width
+	}
+	return math.Min(used/floor, 1)
+}
+
+// presetHeadroom is the fraction of spare capacity BestPresetFor requires
+// beyond a request's total carton volume and weight. Real loads never fill a
+// pallet's bounding volume, so a preset that merely matches the totals would
+// almost certainly overflow; 0.25 means the preset must offer at least 125%
+// of what the cartons need.
+const presetHeadroom = 0.25
+
+// BestPresetFor picks the smallest preset, by volume, whose volume and weight
+// capacity exceed the request's carton totals by presetHeadroom and on which
+// every carton fits in at least one of its allowed orientations. It is a
+// quick heuristic for narrowing the choice before a solve, not a guarantee
+// that the cartons will fit on one pallet. It returns false if no preset
+// qualifies.
+func BestPresetFor(req *PackingRequest, presets []PackingConstraints) (PackingConstraints, bool) {
+	volume := req.TotalVolume()
+	var weight float64
+	for _, c := range req.Cartons {
+		weight += c.Weight * float64(c.Quantity)
+	}
+
+	var best PackingConstraints
+	var bestVolume float64
+	found := false
+	for _, p := range presets {
+		capacity := p.MaxLength * p.MaxWidth * p.MaxHeight
+		if capacity < volume*(1+presetHeadroom) || p.MaxWeight < weight*(1+presetHeadroom) {
+			continue
+		}
+		if !allCartonsFit(req.Cartons, p) {
+			continue
+		}
+		if !found || capacity < bestVolume {
+			best, bestVolume, found = p, capacity, true
+		}
+	}
+	return best, found
+}
+
+// allCartonsFit reports whether every carton fits within the preset's
+// dimensions in at least one orientation
+func allCartonsFit(cartons []Carton, p PackingConstraints) bool {
+	for _, c := range cartons {
+		if !c.fitsWithin(p) {
+			return false
+		}
+	}
+	return true
+}
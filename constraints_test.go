@@ -0,0 +1,68 @@
+package palletizer
+
+import "testing"
+
+func TestPalletsPerFloor(t *testing.T) {
+	pallet := StandardPallet4048() // 1016 x 1219.2
+
+	tests := []struct {
+		name     string
+		floor    Dimensions
+		expected int
+	}{
+		{"exact grid", Dimensions{Length: 2032, Width: 2438.4}, 4},
+		{"rotated fits better", Dimensions{Length: 1219.2, Width: 3048}, 3},
+		{"too small", Dimensions{Length: 1000, Width: 1000}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PalletsPerFloor(pallet, tt.floor); got != tt.expected {
+				t.Errorf("expected %d pallets, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruckFloorUtilization(t *testing.T) {
+	pallet := Pallet{Cartons: []PlacedCarton{{Dimensions: Dimensions{Length: 1000, Width: 1000, Height: 500}}}}
+	truck := PackingConstraints{MaxLength: 4000, MaxWidth: 2000}
+
+	if got := TruckFloorUtilization([]Pallet{pallet, pallet}, truck); got != 0.25 {
+		t.Errorf("expected 0.25, got %f", got)
+	}
+	many := make([]Pallet, 10)
+	for i := range many {
+		many[i] = pallet
+	}
+	if got := TruckFloorUtilization(many, truck); got != 1 {
+		t.Errorf("expected utilization capped at 1, got %f", got)
+	}
+}
+
+func TestBestPresetFor(t *testing.T) {
+	presets := []PackingConstraints{StandardPallet(), StandardPallet4048()}
+
+	small := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 10}}}
+	best, ok := BestPresetFor(small, presets)
+	if !ok || best != StandardPallet4048() {
+		t.Errorf("expected the 40x48 pallet, got %+v (ok=%v)", best, ok)
+	}
+
+	// 1.375 m^3 of cartons needs 1.72 m^3 with headroom, more than the 40x48
+	// pallet's 1.51 m^3 but within the 40x72 pallet's 2.27 m^3
+	large := &PackingRequest{Cartons: []Carton{{ID: "BOX002", Length: 500, Width: 500, Height: 500, Weight: 1000, Quantity: 11}}}
+	best, ok = BestPresetFor(large, presets)
+	if !ok || best != StandardPallet() {
+		t.Errorf("expected the 40x72 pallet, got %+v (ok=%v)", best, ok)
+	}
+
+	heavy := &PackingRequest{Cartons: []Carton{{ID: "BOX003", Length: 100, Width: 100, Height: 100, Weight: 600000, Quantity: 1}}}
+	if _, ok := BestPresetFor(heavy, presets); ok {
+		t.Error("expected no preset to fit a load within headroom of the weight limit")
+	}
+
+	long := &PackingRequest{Cartons: []Carton{{ID: "POLE", Length: 2000, Width: 100, Height: 100, Weight: 1000, Quantity: 1}}}
+	if _, ok := BestPresetFor(long, presets); ok {
+		t.Error("expected no preset to fit a carton longer than every pallet")
+	}
+}
@@ -0,0 +1,57 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+)
+
+// EstimateTime asks the server how long it expects to spend solving the
+// request, which helps choose between Pack and SubmitJob. If the server does
+// not offer an estimate endpoint, a client-side estimate based on the number
+// of cartons is returned instead.
+func (c *Client) EstimateTime(ctx context.Context, request *PackingRequest) (time.Duration, error) {
+	var estimate struct {
+		EstimatedTimeMs int64 `json:"estimated_time_ms"`
+	}
+	err := c.do(ctx, "POST", "/api/v1/estimate", c.withDefaults(request), &estimate)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return estimateComputeTime(cartonCount(request.Cartons)), nil
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(estimate.EstimatedTimeMs) * time.Millisecond, nil
+}
+
+// EstimatePallets returns how many pallets the request needs, for quoting
+// when exact placements are not required. The request is sent with
+// PackingOptions.DryRun set, so a server that supports dry runs skips
+// building placements and answers faster; a server that does not performs a
+// full solve, and the count is the same. The request itself is not modified.
+func (c *Client) EstimatePallets(ctx context.Context, request *PackingRequest) (int, error) {
+	dryRun := *request
+	dryRun.PackingOptions.DryRun = true
+	response, err := c.Pack(ctx, &dryRun)
+	if err != nil {
+		return 0, err
+	}
+	return response.Summary.TotalPallets, nil
+}
+
+// estimateComputeTime approximates server computation time for n cartons.
+// The curve is fitted to published benchmarks (1,000 cartons in ~1.6s,
+// 10,000 in ~86s), which grow slightly faster than n^1.5.
+func estimateComputeTime(n int) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	ms := 1600 * math.Pow(float64(n)/1000, 1.7)
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,86 @@
+package palletizer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/estimate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"estimated_time_ms":1500}`))
+	}))
+	defer server.Close()
+
+	estimate, err := NewWithEndpoint(server.URL).EstimateTime(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("EstimateTime failed: %v", err)
+	}
+	if estimate != 1500*time.Millisecond {
+		t.Errorf("expected 1.5s, got %v", estimate)
+	}
+}
+
+func TestEstimateTimeFallback(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: 1000}}}
+	estimate, err := NewWithEndpoint(server.URL).EstimateTime(context.Background(), request)
+	if err != nil {
+		t.Fatalf("EstimateTime failed: %v", err)
+	}
+	if estimate != 1600*time.Millisecond {
+		t.Errorf("expected 1.6s heuristic estimate, got %v", estimate)
+	}
+}
+
+func TestEstimatePallets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			PackingOptions map[string]any `json:"packing_options"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if body.PackingOptions["dry_run"] != true {
+			t.Errorf("expected dry_run to be sent, got %v", body.PackingOptions)
+		}
+		w.Write([]byte(`{"pallets":[],"summary":{"total_pallets":4}}`))
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: 80}}}
+	n, err := client.EstimatePallets(context.Background(), request)
+	if err != nil {
+		t.Fatalf("EstimatePallets failed: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("expected 4 pallets, got %d", n)
+	}
+	if request.PackingOptions.DryRun {
+		t.Error("expected the caller's request to be unchanged")
+	}
+}
+
+func TestDryRunOmittedByDefault(t *testing.T) {
+	out, err := json.Marshal(PackingOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "dry_run") {
+		t.Errorf("expected dry_run to be omitted, got %s", out)
+	}
+	out, _ = json.Marshal(PackingOptions{DryRun: true})
+	if !strings.Contains(string(out), `"dry_run":true`) {
+		t.Errorf("expected dry_run to be sent, got %s", out)
+	}
+}
@@ -0,0 +1,59 @@
+package palletizer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// placementColumns are the CSV columns written for each placed carton
+var placementColumns = []string{"carton_id", "x", "y", "z", "length", "width", "height", "orientation", "weight", "layer"}
+
+// WriteCSV writes the pallet's placed cartons to w as CSV: a header row, then
+// one row per carton with the columns carton_id, x, y, z, length, width,
+// height, orientation, weight and layer. Positions and dimensions are
+// millimeters and weights grams.
+func (p Pallet) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(placementColumns)
+	for _, c := range p.Cartons {
+		writer.Write(placementRecord(c))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCSV writes the placed cartons of every pallet in the response to w as
+// CSV, in the format of Pallet.WriteCSV with a leading pallet_id column
+func (r *PackingResponse) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(append([]string{"pallet_id"}, placementColumns...))
+	for _, p := range r.Pallets {
+		id := strconv.Itoa(p.PalletID)
+		for _, c := range p.Cartons {
+			writer.Write(append([]string{id}, placementRecord(c)...))
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// placementRecord returns the CSV fields of a placed carton, in the order of
+// placementColumns
+func placementRecord(c PlacedCarton) []string {
+	number := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return []string{
+		c.CartonID,
+		number(c.Position.X),
+		number(c.Position.Y),
+		number(c.Position.Z),
+		number(c.Dimensions.Length),
+		number(c.Dimensions.Width),
+		number(c.Dimensions.Height),
+		string(c.Orientation),
+		number(c.Weight),
+		strconv.Itoa(c.Layer),
+	}
+}
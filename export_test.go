@@ -0,0 +1,46 @@
+package palletizer
+
+import (
+	"strings"
+	"testing"
+)
+
+var csvFixture = Pallet{
+	PalletID: 1,
+	Cartons: []PlacedCarton{
+		{CartonID: "BOX001_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 400, Width: 300, Height: 200}, Orientation: "original", Weight: 5000, Layer: 0},
+		{CartonID: "BOX002_1", Position: Point3D{X: 0, Y: 0, Z: 200}, Dimensions: Dimensions{Length: 300, Width: 400, Height: 150.5}, Orientation: "rotated_lw", Weight: 2500.25, Layer: 1},
+	},
+}
+
+func TestPalletWriteCSV(t *testing.T) {
+	var b strings.Builder
+	if err := csvFixture.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	expected := "carton_id,x,y,z,length,width,height,orientation,weight,layer\n" +
+		"BOX001_1,0,0,0,400,300,200,original,5000,0\n" +
+		"BOX002_1,0,0,200,300,400,150.5,rotated_lw,2500.25,1\n"
+	if b.String() != expected {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", b.String(), expected)
+	}
+}
+
+func TestResponseWriteCSV(t *testing.T) {
+	second := Pallet{PalletID: 2, Cartons: []PlacedCarton{{CartonID: "BOX003_1", Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}, Weight: 750}}}
+	response := &PackingResponse{Pallets: []Pallet{csvFixture, second}}
+
+	var b strings.Builder
+	if err := response.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	expected := "pallet_id,carton_id,x,y,z,length,width,height,orientation,weight,layer\n" +
+		"1,BOX001_1,0,0,0,400,300,200,original,5000,0\n" +
+		"1,BOX002_1,0,0,200,300,400,150.5,rotated_lw,2500.25,1\n" +
+		"2,BOX003_1,0,0,0,100,100,100,,750,0\n"
+	if b.String() != expected {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", b.String(), expected)
+	}
+}
@@ -1,3 +1,9 @@
 module github.com/palletizer-app/go-sdk
 
-go 1.21
+go 1.23
+
+require (
+	go.opentelemetry.io/otel v1.34.0
+	go.opentelemetry.io/otel/trace v1.34.0
+	golang.org/x/time v0.10.0
+)
@@ -0,0 +1,53 @@
+package palletizer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// healthCache holds the last successful health check for WithHealthCache
+type healthCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	response  HealthResponse
+	fetchedAt time.Time
+}
+
+// Health checks whether the Palletizer API is up. When the client was created
+// with WithHealthCache, a result younger than the cache TTL is returned
+// without contacting the server.
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	return c.CheckHealth(ctx, false)
+}
+
+// CheckHealth is like Health, but when force is true it always contacts the
+// server, refreshing the cache if one is configured
+func (c *Client) CheckHealth(ctx context.Context, force bool) (*HealthResponse, error) {
+	cache := c.healthCache
+	if cache != nil && !force {
+		cache.mu.Lock()
+		fresh := !cache.fetchedAt.IsZero() && time.Since(cache.fetchedAt) < cache.ttl
+		response := cache.response
+		cache.mu.Unlock()
+		if fresh {
+			return &response, nil
+		}
+	}
+
+	// The lock is not held during the call, so a slow server holds up only
+	// the callers that reach it, each bounded by its own context
+	var response HealthResponse
+	if err := c.do(ctx, "GET", "/health", nil, &response); err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		cache.mu.Lock()
+		cache.response = response
+		cache.fetchedAt = time.Now()
+		cache.mu.Unlock()
+	}
+	return &response, nil
+}
@@ -0,0 +1,108 @@
+package palletizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHealthCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithHealthCache(time.Hour))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		health, err := client.Health(ctx)
+		if err != nil {
+			t.Fatalf("Health failed: %v", err)
+		}
+		if health.Status != "ok" {
+			t.Errorf("expected status ok, got %s", health.Status)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected 1 server call, got %d", n)
+	}
+
+	if _, err := client.CheckHealth(ctx, true); err != nil {
+		t.Fatalf("CheckHealth failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected forced check to reach the server, got %d calls", n)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/health" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	health, err := NewWithEndpoint(server.URL).Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("expected status ok, got %s", health.Status)
+	}
+}
+
+func TestHealthUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("database unreachable"))
+	}))
+	defer server.Close()
+
+	_, err := NewWithEndpoint(server.URL).Health(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != "API returned status 503: database unreachable" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHealthCacheDoesNotSerializeCalls(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithHealthCache(time.Hour))
+	client.baseURL = server.URL
+
+	// A call stuck on the server must not keep other callers past their
+	// own deadlines
+	go client.Health(context.Background())
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Health(ctx); err == nil {
+		t.Fatal("expected the deadline to end the call")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the call to end at its deadline, took %v", elapsed)
+	}
+}
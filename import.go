@@ -0,0 +1,207 @@
+package palletizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// ReadRequestsJSONL returns an iterator over the packing requests in r, one
+// JSON object per line. Lines are read one at a time, so r is never loaded
+// into memory in full. Blank lines are skipped. A line that fails to parse
+// yields a nil request and an error naming the line number, and iteration
+// continues with the next line; a read error ends the iteration.
+func ReadRequestsJSONL(r io.Reader) iter.Seq2[*PackingRequest, error] {
+	return func(yield func(*PackingRequest, error) bool) {
+		reader := bufio.NewReader(r)
+		for lineNum := 1; ; lineNum++ {
+			line, err := reader.ReadBytes('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield(nil, fmt.Errorf("line %d: failed to read: %w", lineNum, err))
+				return
+			}
+
+			if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+				var request PackingRequest
+				if jsonErr := json.Unmarshal(trimmed, &request); jsonErr != nil {
+					if !yield(nil, fmt.Errorf("line %d: failed to parse request: %w", lineNum, jsonErr)) {
+						return
+					}
+				} else if !yield(&request, nil) {
+					return
+				}
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ReadCartonsTSV reads cartons from tab-separated text, such as cells copied
+// from a spreadsheet. The first row is a header naming the columns (in any
+// order, case-insensitive): id, length, width, height and weight are
+// required; quantity (default 1), fragile and allow_rotation are optional and
+// other columns are ignored. Dimensions are millimeters and weights grams. A
+// units row directly below the header (a blank id, with "mm", "g" and the
+// like in the numeric cells) is skipped. A malformed cell is reported with
+// its spreadsheet coordinate, e.g. "C4".
+func ReadCartonsTSV(r io.Reader) ([]Carton, error) {
+	return readCartons(r, '\t')
+}
+
+// readCartons reads cartons from delimited text with a header row
+func readCartons(r io.Reader, comma rune) ([]Carton, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing header row")
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"id", "length", "width", "height", "weight"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	var cartons []Carton
+	for rowNum := 2; ; rowNum++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+
+		cell := func(name string) (string, int) {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return "", i
+			}
+			return strings.TrimSpace(record[i]), i
+		}
+		if id, _ := cell("id"); rowNum == 2 && id == "" && isUnitsRow(record, columns) {
+			continue
+		}
+
+		// number parses a numeric cell; a blank optional cell yields def
+		number := func(name string, def float64, required bool) (float64, error) {
+			value, col := cell(name)
+			if value == "" {
+				if required {
+					return 0, fmt.Errorf("%s: missing %s", cellRef(col, rowNum), name)
+				}
+				return def, nil
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%s: invalid %s %q", cellRef(col, rowNum), name, value)
+			}
+			return v, nil
+		}
+		flag := func(name string) (bool, error) {
+			value, col := cell(name)
+			switch strings.ToLower(value) {
+			case "", "0", "false", "no", "n":
+				return false, nil
+			case "1", "true", "yes", "y":
+				return true, nil
+			}
+			return false, fmt.Errorf("%s: invalid %s %q", cellRef(col, rowNum), name, value)
+		}
+
+		id, _ := cell("id")
+		carton := Carton{ID: id}
+		fields := []struct {
+			name string
+			dst  *float64
+		}{
+			{"length", &carton.Length},
+			{"width", &carton.Width},
+			{"height", &carton.Height},
+			{"weight", &carton.Weight},
+		}
+		for _, f := range fields {
+			if *f.dst, err = number(f.name, 0, true); err != nil {
+				return nil, err
+			}
+		}
+		quantity, err := number("quantity", 1, false)
+		if err != nil {
+			return nil, err
+		}
+		if quantity != float64(int(quantity)) {
+			value, col := cell("quantity")
+			return nil, fmt.Errorf("%s: invalid quantity %q", cellRef(col, rowNum), value)
+		}
+		carton.Quantity = int(quantity)
+		if carton.Fragile, err = flag("fragile"); err != nil {
+			return nil, err
+		}
+		if carton.AllowRotation, err = flag("allow_rotation"); err != nil {
+			return nil, err
+		}
+		cartons = append(cartons, carton)
+	}
+	return cartons, nil
+}
+
+// isUnitsRow reports whether record holds unit labels rather than values:
+// every non-blank dimension cell is a length unit such as "mm", a non-blank
+// weight cell is a weight unit such as "kg", the quantity cell is blank and
+// at least one label is present.
+func isUnitsRow(record []string, columns map[string]int) bool {
+	labels := 0
+	for _, name := range []string{"length", "width", "height", "weight", "quantity"} {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			continue
+		}
+		value := strings.ToLower(strings.TrimSpace(record[i]))
+		if value == "" {
+			continue
+		}
+		var known bool
+		switch name {
+		case "weight":
+			_, known = weightUnits[value]
+		case "quantity":
+		default:
+			_, known = lengthUnits[value]
+		}
+		if !known {
+			return false
+		}
+		labels++
+	}
+	return labels > 0
+}
+
+// cellRef returns the spreadsheet coordinate of a zero-based column and a
+// one-based row, e.g. cellRef(2, 4) is "C4"
+func cellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
@@ -0,0 +1,85 @@
+package palletizer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadRequestsJSONL(t *testing.T) {
+	input := `{"cartons":[{"id":"BOX001","quantity":2}]}
+
+{"cartons": nope}
+{"cartons":[{"id":"BOX002","quantity":1}]}`
+
+	var ids []string
+	var errs []error
+	for request, err := range ReadRequestsJSONL(strings.NewReader(input)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, request.Cartons[0].ID)
+	}
+
+	if len(ids) != 2 || ids[0] != "BOX001" || ids[1] != "BOX002" {
+		t.Errorf("expected requests [BOX001 BOX002], got %v", ids)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(errs))
+	}
+	if !strings.HasPrefix(errs[0].Error(), "line 3:") {
+		t.Errorf("expected error for line 3, got %v", errs[0])
+	}
+}
+
+func TestReadCartonsTSV(t *testing.T) {
+	input := "ID\tLength\tWidth\tHeight\tWeight\tQuantity\tFragile\tAllow_Rotation\n" +
+		"\tmm\tmm\tmm\tg\t\t\t\n" +
+		"BOX001\t609.6\t457.2\t406.4\t18143.68\t30\tno\tyes\n" +
+		"BOX002\t300\t200\t100\t500\t\tTRUE\t\n"
+
+	cartons, err := ReadCartonsTSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCartonsTSV failed: %v", err)
+	}
+	expected := []Carton{
+		{ID: "BOX001", Length: 609.6, Width: 457.2, Height: 406.4, Weight: 18143.68, Quantity: 30, AllowRotation: true},
+		{ID: "BOX002", Length: 300, Width: 200, Height: 100, Weight: 500, Quantity: 1, Fragile: true},
+	}
+	if len(cartons) != len(expected) {
+		t.Fatalf("expected %d cartons, got %d", len(expected), len(cartons))
+	}
+	for i := range expected {
+		if cartons[i] != expected[i] {
+			t.Errorf("carton %d: expected %+v, got %+v", i, expected[i], cartons[i])
+		}
+	}
+}
+
+func TestReadCartonsTSVInvalidCell(t *testing.T) {
+	input := "id\tlength\twidth\theight\tweight\n" +
+		"BOX001\t100\t100\t100\t500\n" +
+		"BOX002\t100\t1O0\t100\t500\n"
+
+	_, err := ReadCartonsTSV(strings.NewReader(input))
+	if err == nil || !strings.HasPrefix(err.Error(), "C3:") {
+		t.Errorf("expected error at C3, got %v", err)
+	}
+}
+
+func TestReadCartonsTSVMalformedRowNotSkipped(t *testing.T) {
+	header := "id\tlength\twidth\theight\tweight\n"
+	tests := []struct {
+		name, input, cell string
+	}{
+		{"filled id", header + "BOX1\t100\t100\t100\t500\nBOX2\tabc\t\t\t\n", "B3"},
+		{"not below header", header + "BOX1\t100\t100\t100\t500\n\tmm\tmm\tmm\tg\n", "B3"},
+		{"unknown label", header + "\tabc\tmm\tmm\tg\nBOX1\t100\t100\t100\t500\n", "B2"},
+	}
+	for _, tt := range tests {
+		_, err := ReadCartonsTSV(strings.NewReader(tt.input))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.cell+":") {
+			t.Errorf("%s: expected error at %s, got %v", tt.name, tt.cell, err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Job status values reported by the async endpoints
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// JobStatus is the state of an asynchronous packing job
+type JobStatus struct {
+	ID       string           `json:"id"`
+	Status   string           `json:"status"`
+	Progress float64          `json:"progress"` // percent complete (0-100)
+	Result   *PackingResponse `json:"result,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// defaultMaxJobs caps how many jobs ListAllJobs collects when
+// JobFilter.MaxResults is not set
+const defaultMaxJobs = 10000
+
+// ErrJobLimitReached is returned by ListAllJobs when more jobs match than
+// the filter's MaxResults allows
+var ErrJobLimitReached = errors.New("job limit reached")
+
+// JobSummary describes an asynchronous job in a job listing
+type JobSummary struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at"` // zero until the job finishes
+}
+
+// JobFilter scopes a job listing. Zero fields do not filter.
+type JobFilter struct {
+	Status     string    // only jobs with this status
+	Since      time.Time // only jobs created at or after this time
+	Until      time.Time // only jobs created before this time
+	MaxResults int       // most jobs to collect (default 10000)
+}
+
+// Job is a handle to an asynchronous packing job submitted with SubmitJob
+type Job struct {
+	ID     string
+	client *Client
+}
+
+// SubmitJob submits a packing request to the async endpoint and returns a
+// handle that can wait for or cancel the job
+func (c *Client) SubmitJob(ctx context.Context, request *PackingRequest) (*Job, error) {
+	var status JobStatus
+	if err := c.do(ctx, "POST", "/api/v1/pack/async", c.withDefaults(request), &status); err != nil {
+		return nil, err
+	}
+	if status.ID == "" {
+		return nil, errors.New("API returned no job ID")
+	}
+	return &Job{ID: status.ID, client: c}, nil
+}
+
+// PackAsync submits a packing request to the async endpoint, for solves that
+// may outlast the client timeout. It is equivalent to SubmitJob; the job
+// can be polled with PollJob or awaited with Job.Wait.
+//
+// Deprecated: Use SubmitJob.
+func (c *Client) PackAsync(ctx context.Context, request *PackingRequest) (*Job, error) {
+	return c.SubmitJob(ctx, request)
+}
+
+// PollJob checks the job with the given ID once and reports whether it has
+// finished. A completed job returns its result; a failed or cancelled job
+// returns done with an error; a job still queued or running returns a nil
+// response, false and no error.
+func (c *Client) PollJob(ctx context.Context, jobID string) (*PackingResponse, bool, error) {
+	status, err := c.jobStatus(ctx, jobID)
+	if err != nil {
+		return nil, false, err
+	}
+	return status.outcome(jobID)
+}
+
+// Status fetches the current status of the job
+func (j *Job) Status(ctx context.Context) (*JobStatus, error) {
+	return j.client.jobStatus(ctx, j.ID)
+}
+
+// Wait polls the job until it finishes and returns its result. It returns an
+// error if the job fails or is cancelled, or if ctx is done first.
+func (j *Job) Wait(ctx context.Context) (*PackingResponse, error) {
+	return j.client.WaitForResultWithProgress(ctx, j.ID, j.client.pollInterval, nil)
+}
+
+// WaitForResultWithProgress polls the job with the given ID every
+// pollInterval until it finishes, sending each polled status to progress, and
+// returns the job's result. The progress channel is closed when the method
+// returns; a nil channel disables progress reporting. A pollInterval of zero
+// or less uses the client's poll interval.
+func (c *Client) WaitForResultWithProgress(ctx context.Context, jobID string, pollInterval time.Duration, progress chan<- JobStatus) (*PackingResponse, error) {
+	if progress != nil {
+		defer close(progress)
+	}
+	if pollInterval <= 0 {
+		pollInterval = c.pollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.jobStatus(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			select {
+			case progress <- *status:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		if response, done, err := status.outcome(jobID); done {
+			return response, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// outcome reports whether the job with the given ID has finished and, if
+// so, its result or the error it finished with
+func (s *JobStatus) outcome(jobID string) (*PackingResponse, bool, error) {
+	switch s.Status {
+	case JobStatusCompleted:
+		if s.Result == nil {
+			return nil, true, fmt.Errorf("job %s completed without a result", jobID)
+		}
+		return s.Result, true, nil
+	case JobStatusFailed:
+		return nil, true, fmt.Errorf("job %s failed: %s", jobID, s.Error)
+	case JobStatusCancelled:
+		return nil, true, fmt.Errorf("job %s was cancelled", jobID)
+	}
+	return nil, false, nil
+}
+
+// Cancel asks the server to cancel the job
+func (j *Job) Cancel(ctx context.Context) error {
+	return j.client.do(ctx, "DELETE", "/api/v1/jobs/"+url.PathEscape(j.ID), nil, nil)
+}
+
+// jobStatus fetches the status of the job with the given ID
+func (c *Client) jobStatus(ctx context.Context, jobID string) (*JobStatus, error) {
+	var status JobStatus
+	if err := c.do(ctx, "GET", "/api/v1/jobs/"+url.PathEscape(jobID), nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ListAllJobs returns every job matching filter, following the server's
+// pagination cursors until the listing is exhausted. If more than
+// filter.MaxResults jobs match, the first MaxResults are returned together
+// with an error wrapping ErrJobLimitReached.
+func (c *Client) ListAllJobs(ctx context.Context, filter JobFilter) ([]JobSummary, error) {
+	limit := filter.MaxResults
+	if limit <= 0 {
+		limit = defaultMaxJobs
+	}
+
+	query := url.Values{}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.UTC().Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		query.Set("until", filter.Until.UTC().Format(time.RFC3339))
+	}
+
+	var jobs []JobSummary
+	cursor := ""
+	for {
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var page struct {
+			Jobs       []JobSummary `json:"jobs"`
+			NextCursor string       `json:"next_cursor"`
+		}
+		if err := c.do(ctx, "GET", "/api/v1/jobs?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, page.Jobs...)
+		if len(jobs) > limit {
+			return jobs[:limit], fmt.Errorf("listing jobs: %w (%d)", ErrJobLimitReached, limit)
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return jobs, nil
+		}
+		cursor = page.NextCursor
+	}
+}
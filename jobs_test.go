@@ -0,0 +1,213 @@
+package palletizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubmitJobWait(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/pack/async":
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(JobStatus{ID: "job-1", Status: JobStatusQueued})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/jobs/job-1":
+			status := JobStatus{ID: "job-1", Status: JobStatusRunning, Progress: 50}
+			if atomic.AddInt32(&polls, 1) >= 3 {
+				status.Status = JobStatusCompleted
+				status.Result = &PackingResponse{Summary: PackingSummary{TotalPallets: 2}}
+			}
+			json.NewEncoder(w).Encode(status)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithEndpoint(server.URL)
+	client.pollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := client.SubmitJob(ctx, &PackingRequest{})
+	if err != nil {
+		t.Fatalf("SubmitJob failed: %v", err)
+	}
+	if job.ID != "job-1" {
+		t.Errorf("expected job ID job-1, got %s", job.ID)
+	}
+
+	response, err := job.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if response.Summary.TotalPallets != 2 {
+		t.Errorf("expected 2 pallets, got %d", response.Summary.TotalPallets)
+	}
+	if n := atomic.LoadInt32(&polls); n != 3 {
+		t.Errorf("expected 3 polls, got %d", n)
+	}
+}
+
+func TestJobCancel(t *testing.T) {
+	var cancelled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v1/jobs/job-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		cancelled = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	job := &Job{ID: "job-1", client: NewWithEndpoint(server.URL)}
+	if err := job.Cancel(context.Background()); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if !cancelled {
+		t.Error("expected cancel request to reach the server")
+	}
+}
+
+func TestWaitForResultWithProgress(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&polls, 1)
+		status := JobStatus{ID: "job-1", Status: JobStatusRunning, Progress: float64(n) * 25}
+		if n == 4 {
+			status.Status = JobStatusCompleted
+			status.Result = &PackingResponse{}
+		}
+		json.NewEncoder(w).Encode(status)
+	}))
+	defer server.Close()
+
+	client := NewWithEndpoint(server.URL)
+	progress := make(chan JobStatus)
+	var seen []float64
+	done := make(chan struct{})
+	go func() {
+		for status := range progress {
+			seen = append(seen, status.Progress)
+		}
+		close(done)
+	}()
+
+	if _, err := client.WaitForResultWithProgress(context.Background(), "job-1", time.Millisecond, progress); err != nil {
+		t.Fatalf("WaitForResultWithProgress failed: %v", err)
+	}
+	<-done
+	if len(seen) != 4 || seen[3] != 100 {
+		t.Errorf("expected progress [25 50 75 100], got %v", seen)
+	}
+}
+
+func TestListAllJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/jobs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if status := r.URL.Query().Get("status"); status != JobStatusCompleted {
+			t.Errorf("expected status filter, got %q", status)
+		}
+		// Three pages of two jobs each.
+		page := 0
+		fmt.Sscanf(r.URL.Query().Get("cursor"), "page-%d", &page)
+		var body struct {
+			Jobs       []JobSummary `json:"jobs"`
+			NextCursor string       `json:"next_cursor"`
+		}
+		for i := 0; i < 2; i++ {
+			body.Jobs = append(body.Jobs, JobSummary{ID: fmt.Sprintf("job-%d", page*2+i), Status: JobStatusCompleted})
+		}
+		if page < 2 {
+			body.NextCursor = fmt.Sprintf("page-%d", page+1)
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	client := NewWithEndpoint(server.URL)
+	jobs, err := client.ListAllJobs(context.Background(), JobFilter{Status: JobStatusCompleted})
+	if err != nil {
+		t.Fatalf("ListAllJobs failed: %v", err)
+	}
+	if len(jobs) != 6 || jobs[5].ID != "job-5" {
+		t.Errorf("expected 6 jobs ending with job-5, got %v", jobs)
+	}
+
+	jobs, err = client.ListAllJobs(context.Background(), JobFilter{Status: JobStatusCompleted, MaxResults: 3})
+	if !errors.Is(err, ErrJobLimitReached) {
+		t.Errorf("expected ErrJobLimitReached, got %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Errorf("expected 3 jobs, got %d", len(jobs))
+	}
+}
+
+func TestSubmitJobPollJob(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/pack/async":
+			json.NewEncoder(w).Encode(JobStatus{ID: "job-7", Status: JobStatusQueued})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/jobs/job-7":
+			status := JobStatus{ID: "job-7", Status: JobStatusRunning}
+			if atomic.AddInt32(&polls, 1) >= 2 {
+				status.Status = JobStatusCompleted
+				status.Result = &PackingResponse{Summary: PackingSummary{TotalPallets: 4}}
+			}
+			json.NewEncoder(w).Encode(status)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithPollInterval(time.Millisecond))
+	if client.pollInterval != time.Millisecond {
+		t.Errorf("expected poll interval to be set, got %v", client.pollInterval)
+	}
+	ctx := context.Background()
+
+	job, err := client.SubmitJob(ctx, &PackingRequest{})
+	if err != nil {
+		t.Fatalf("SubmitJob failed: %v", err)
+	}
+
+	response, done, err := client.PollJob(ctx, job.ID)
+	if err != nil || done || response != nil {
+		t.Errorf("expected a running job, got %v, %v, %v", response, done, err)
+	}
+	response, done, err = client.PollJob(ctx, job.ID)
+	if err != nil || !done {
+		t.Fatalf("expected a finished job, got done=%v err=%v", done, err)
+	}
+	if response.Summary.TotalPallets != 4 {
+		t.Errorf("expected 4 pallets, got %d", response.Summary.TotalPallets)
+	}
+}
+
+func TestPollJobFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(JobStatus{ID: "job-8", Status: JobStatusFailed, Error: "solver crashed"})
+	}))
+	defer server.Close()
+
+	_, done, err := NewWithEndpoint(server.URL).PollJob(context.Background(), "job-8")
+	if !done || err == nil || err.Error() != "job job-8 failed: solver crashed" {
+		t.Errorf("expected failed job error, got done=%v err=%v", done, err)
+	}
+}
@@ -0,0 +1,46 @@
+package palletizer
+
+import "context"
+
+// PackLeftovers packs the cartons of req that resp did not place onto
+// additional pallets with the given constraints, keeping req's packing
+// options. If every carton was placed, an empty response is returned without
+// contacting the server. Pallet IDs in the result are numbered by the new
+// solve.
+func (c *Client) PackLeftovers(ctx context.Context, req *PackingRequest, resp *PackingResponse, constraints PackingConstraints) (*PackingResponse, error) {
+	cartons := leftovers(req, resp)
+	if len(cartons) == 0 {
+		return &PackingResponse{Pallets: []Pallet{}}, nil
+	}
+	return c.Pack(ctx, &PackingRequest{
+		Cartons:            cartons,
+		PackingConstraints: constraints,
+		PackingOptions:     req.PackingOptions,
+	})
+}
+
+// leftovers returns the request's cartons with Quantity reduced by the number
+// of each placed in resp, omitting lines that were fully placed. Placed
+// cartons are matched to request lines by cartonFor; placements beyond a
+// line's quantity count toward later lines with the same ID.
+func leftovers(req *PackingRequest, resp *PackingResponse) []Carton {
+	placed := make(map[string]int)
+	for _, p := range resp.Pallets {
+		for _, pc := range p.Cartons {
+			if c, ok := req.cartonFor(pc.CartonID); ok {
+				placed[c.ID]++
+			}
+		}
+	}
+
+	var cartons []Carton
+	for _, c := range req.Cartons {
+		used := min(placed[c.ID], c.Quantity)
+		placed[c.ID] -= used
+		if c.Quantity > used {
+			c.Quantity -= used
+			cartons = append(cartons, c)
+		}
+	}
+	return cartons
+}
@@ -0,0 +1,70 @@
+package palletizer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLeftovers(t *testing.T) {
+	request := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "A", Quantity: 3},
+			{ID: "B", Quantity: 2},
+			{ID: "C", Quantity: 1},
+		},
+	}
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{Cartons: []PlacedCarton{{CartonID: "A_1"}, {CartonID: "A_2"}, {CartonID: "B_1"}}},
+			{Cartons: []PlacedCarton{{CartonID: "B_2"}, {CartonID: "C"}}},
+		},
+	}
+
+	got := leftovers(request, response)
+	if len(got) != 1 || got[0].ID != "A" || got[0].Quantity != 1 {
+		t.Errorf("expected one A left over, got %+v", got)
+	}
+	if request.Cartons[0].Quantity != 3 {
+		t.Error("expected the request to be left unchanged")
+	}
+}
+
+func TestPackLeftovers(t *testing.T) {
+	var received PackingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"pallets":[{"pallet_id":1,"cartons":[{"carton_id":"A_1"}]}]}`))
+	}))
+	defer server.Close()
+	client := NewWithEndpoint(server.URL)
+
+	request := &PackingRequest{
+		Cartons:            []Carton{{ID: "A", Quantity: 2}},
+		PackingConstraints: StandardPallet(),
+		PackingOptions:     PackingOptions{SupportPercentage: 80},
+	}
+	first := &PackingResponse{Pallets: []Pallet{{Cartons: []PlacedCarton{{CartonID: "A_1"}}}}}
+
+	overflow, err := client.PackLeftovers(context.Background(), request, first, EURPallet())
+	if err != nil {
+		t.Fatalf("PackLeftovers failed: %v", err)
+	}
+	if len(overflow.Pallets) != 1 {
+		t.Errorf("expected 1 overflow pallet, got %d", len(overflow.Pallets))
+	}
+	if len(received.Cartons) != 1 || received.Cartons[0].Quantity != 1 {
+		t.Errorf("expected one leftover carton to be sent, got %+v", received.Cartons)
+	}
+	if received.PackingConstraints != EURPallet() || received.PackingOptions.SupportPercentage != 80 {
+		t.Errorf("unexpected constraints or options: %+v", received)
+	}
+
+	full := &PackingResponse{Pallets: []Pallet{{Cartons: []PlacedCarton{{CartonID: "A_1"}, {CartonID: "A_2"}}}}}
+	empty, err := NewWithEndpoint("http://unreachable.invalid").PackLeftovers(context.Background(), request, full, EURPallet())
+	if err != nil || len(empty.Pallets) != 0 {
+		t.Errorf("expected an empty response without a call, got %v, %v", empty, err)
+	}
+}
@@ -0,0 +1,119 @@
+package palletizer
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Directions of a LogEvent
+const (
+	LogDirectionRequest  = "request"
+	LogDirectionResponse = "response"
+)
+
+// maxLogBody is the number of body bytes included in a LogEvent
+const maxLogBody = 4096
+
+// LogEvent describes one side of an HTTP exchange, passed to the hooks set
+// with WithLogger
+type LogEvent struct {
+	Direction string        // LogDirectionRequest or LogDirectionResponse
+	Method    string        // HTTP method as sent
+	URL       string        // full request URL
+	Path      string        // API endpoint, e.g. "/v1/pack"
+	Cartons   int           // carton count of a packing request (0 for other calls)
+	Status    int           // response status (0 for requests and failed sends)
+	Body      string        // JSON body, truncated to 4 KiB; requests only carry one with WithLogger
+	Duration  time.Duration // time taken to receive the response (0 for requests)
+	Err       error         // error the attempt failed with, including error statuses
+}
+
+// slogHook returns a log hook recording each HTTP attempt on logger: at
+// debug level when it succeeds and at error level when it fails, with the
+// endpoint, HTTP status, duration and carton count as attributes
+func slogHook(logger *slog.Logger) func(context.Context, LogEvent) {
+	return func(ctx context.Context, event LogEvent) {
+		if event.Direction != LogDirectionResponse {
+			return
+		}
+		attrs := []slog.Attr{
+			slog.String("method", event.Method),
+			slog.String("endpoint", event.Path),
+			slog.Int("status", event.Status),
+			slog.Duration("duration", event.Duration),
+		}
+		if event.Cartons > 0 {
+			attrs = append(attrs, slog.Int("cartons", event.Cartons))
+		}
+
+		if event.Err != nil {
+			attrs = append(attrs, slog.String("error", event.Err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "palletizer request failed", attrs...)
+			return
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "palletizer request", attrs...)
+	}
+}
+
+// cartonCount returns the number of individual cartons, honoring Quantity
+func cartonCount(cartons []Carton) int {
+	n := 0
+	for _, c := range cartons {
+		n += c.Quantity
+	}
+	return n
+}
+
+// newLogEvent returns an event for req, sent to the API endpoint path with
+// body in
+func newLogEvent(direction string, req *http.Request, path string, in any) LogEvent {
+	event := LogEvent{Direction: direction, Method: req.Method, URL: req.URL.String(), Path: path}
+	if request, ok := in.(*PackingRequest); ok && request != nil {
+		event.Cartons = cartonCount(request.Cartons)
+	}
+	return event
+}
+
+// logRequestEvent passes an outgoing request to the log hooks. With
+// WithLogger the event carries the body in, which is encoded separately from
+// the streamed one, so it is only built then.
+func (c *Client) logRequestEvent(ctx context.Context, req *http.Request, path string, in any) {
+	event := newLogEvent(LogDirectionRequest, req, path, in)
+	if c.logBodies && in != nil {
+		if body, err := json.Marshal(in); err == nil {
+			event.Body = truncateBody(body)
+		}
+	}
+	c.emitLogEvent(ctx, event)
+}
+
+// logResponseEvent passes a response, or the error that prevented one, to
+// the log hooks
+func (c *Client) logResponseEvent(ctx context.Context, req *http.Request, path string, in any, status int, body []byte, duration time.Duration, err error) {
+	event := newLogEvent(LogDirectionResponse, req, path, in)
+	event.Status = status
+	event.Body = truncateBody(body)
+	event.Duration = duration
+	event.Err = err
+	c.emitLogEvent(ctx, event)
+}
+
+// emitLogEvent calls every log hook with event, in the order they were
+// registered
+func (c *Client) emitLogEvent(ctx context.Context, event LogEvent) {
+	for _, hook := range c.logHooks {
+		hook(ctx, event)
+	}
+}
+
+// truncateBody returns body as a string of at most maxLogBody bytes, marking
+// truncation with a trailing "..."
+func truncateBody(body []byte) string {
+	if len(body) <= maxLogBody {
+		return string(body)
+	}
+	return string(body[:maxLogBody]) + "..."
+}
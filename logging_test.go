@@ -0,0 +1,136 @@
+package palletizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithSlog(t *testing.T) {
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"no cartons"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(WithSlog(logger))
+	client.baseURL = server.URL
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: 3}, {ID: "BOX002", Quantity: 2}}}
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	fail = true
+	if _, err := client.Pack(context.Background(), request); err == nil {
+		t.Fatal("expected error")
+	}
+
+	dec := json.NewDecoder(&buf)
+	var records []map[string]any
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("failed to decode log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 log records, got %d", len(records))
+	}
+	if records[0]["level"] != "DEBUG" || records[0]["endpoint"] != "/v1/pack" || records[0]["status"] != 200.0 || records[0]["cartons"] != 5.0 {
+		t.Errorf("unexpected debug record: %v", records[0])
+	}
+	if records[1]["level"] != "ERROR" || records[1]["status"] != 400.0 || records[1]["error"] == nil {
+		t.Errorf("unexpected error record: %v", records[1])
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	var events []LogEvent
+	client := NewClient(WithLogger(func(event LogEvent) {
+		events = append(events, event)
+	}))
+	client.baseURL = server.URL
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: 1}}}
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	req, resp := events[0], events[1]
+	if req.Direction != LogDirectionRequest || req.Method != "POST" || req.URL != server.URL+"/v1/pack" {
+		t.Errorf("unexpected request event: %+v", req)
+	}
+	if !bytes.Contains([]byte(req.Body), []byte(`"BOX001"`)) {
+		t.Errorf("expected request body to include the carton, got %q", req.Body)
+	}
+	if resp.Direction != LogDirectionResponse || resp.Status != http.StatusOK || resp.Body != `{"pallets":[]}` || resp.Err != nil {
+		t.Errorf("unexpected response event: %+v", resp)
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	long := bytes.Repeat([]byte("a"), maxLogBody+10)
+	if got := truncateBody(long); len(got) != maxLogBody+3 || got[maxLogBody:] != "..." {
+		t.Errorf("expected body truncated to %d bytes, got %d", maxLogBody, len(got))
+	}
+	if got := truncateBody([]byte("short")); got != "short" {
+		t.Errorf("expected short body unchanged, got %q", got)
+	}
+}
+
+func TestWithSlogSharesLoggerHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	var events []LogEvent
+	client := NewClient(
+		WithEndpoint(server.URL),
+		WithSlog(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithLogger(func(event LogEvent) { events = append(events, event) }),
+	)
+	if len(client.logHooks) != 2 {
+		t.Fatalf("expected WithSlog and WithLogger to share the hook point, got %d hooks", len(client.logHooks))
+	}
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: 2}}}
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if len(events) != 2 || events[1].Path != "/v1/pack" || events[1].Cartons != 2 {
+		t.Errorf("unexpected events: %+v", events)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected one slog record, got %q", buf.String())
+	}
+	if record["endpoint"] != "/v1/pack" || record["cartons"] != 2.0 {
+		t.Errorf("unexpected slog record: %v", record)
+	}
+
+	if NewClient(WithSlog(slog.Default())).logBodies {
+		t.Error("expected WithSlog alone not to capture request bodies")
+	}
+}
@@ -0,0 +1,64 @@
+package palletizer
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// correlationIDHeader carries the per-call ID set by WithAutoCorrelationID
+const correlationIDHeader = "X-Correlation-ID"
+
+// requestIDHeader carries the server's ID for a request
+const requestIDHeader = "X-Request-ID"
+
+// ResponseMeta describes the HTTP exchange behind an API call
+type ResponseMeta struct {
+	StatusCode    int         // HTTP status of the final attempt (0 if no response was received)
+	Header        http.Header // response headers of the final attempt
+	CorrelationID string      // ID sent as X-Correlation-ID, if WithAutoCorrelationID is set
+	RequestID     string      // server-assigned X-Request-ID of the final attempt, if any
+}
+
+// PackWithResponse is like Pack but also returns metadata about the HTTP
+// exchange: the status code, headers and server request ID, along with the
+// correlation ID sent with the request. The metadata is returned even when
+// err is non-nil, so failed calls can be traced too.
+func (c *Client) PackWithResponse(ctx context.Context, request *PackingRequest) (*PackingResponse, *ResponseMeta, error) {
+	request = c.withDefaults(request)
+	ctx, endSpan := c.startPackSpan(ctx, request)
+	var response PackingResponse
+	meta, err := c.doMeta(ctx, "POST", "/v1/pack", request, &response)
+	if err != nil {
+		endSpan(nil, err)
+		return nil, meta, err
+	}
+	endSpan(&response, nil)
+	return &response, meta, nil
+}
+
+// PackWithMeta is equivalent to PackWithResponse.
+//
+// Deprecated: Use PackWithResponse.
+func (c *Client) PackWithMeta(ctx context.Context, request *PackingRequest) (*PackingResponse, *ResponseMeta, error) {
+	return c.PackWithResponse(ctx, request)
+}
+
+// newCorrelationID returns a fresh correlation ID for a call, or "" when
+// correlation IDs are disabled
+func (c *Client) newCorrelationID() string {
+	if c.idGenerator == nil {
+		return ""
+	}
+	return c.idGenerator()
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,69 @@
+package palletizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestPackWithResponseCorrelationID(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("X-Correlation-ID")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithIDGenerator(func() string { return "test-id-1" }))
+	client.baseURL = server.URL
+
+	_, meta, err := client.PackWithResponse(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("PackWithResponse failed: %v", err)
+	}
+	if received != "test-id-1" || meta.CorrelationID != "test-id-1" {
+		t.Errorf("expected correlation ID test-id-1, got header %q meta %q", received, meta.CorrelationID)
+	}
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", meta.StatusCode)
+	}
+
+	client = NewClient(WithAutoCorrelationID())
+	client.baseURL = server.URL
+	_, meta, err = client.PackWithResponse(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("PackWithResponse failed: %v", err)
+	}
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !uuid.MatchString(meta.CorrelationID) || received != meta.CorrelationID {
+		t.Errorf("expected generated UUID, got header %q meta %q", received, meta.CorrelationID)
+	}
+
+	if _, err := NewWithEndpoint(server.URL).Pack(context.Background(), &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if received != "" {
+		t.Errorf("expected no correlation ID by default, got %q", received)
+	}
+}
+
+func TestPackWithResponseRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-4711")
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	response, meta, err := NewWithEndpoint(server.URL).PackWithResponse(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("PackWithResponse failed: %v", err)
+	}
+	if response == nil {
+		t.Fatal("expected response")
+	}
+	if meta.RequestID != "req-4711" || meta.StatusCode != http.StatusOK || meta.Header.Get("X-Request-ID") != "req-4711" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+}
@@ -0,0 +1,28 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotSupported is returned when the server does not implement an
+// endpoint, typically because it predates the feature
+var ErrNotSupported = errors.New("endpoint not supported by server")
+
+// Metrics fetches the server's operational metrics. Servers that predate the
+// metrics endpoint answer with 404, which is reported as an error wrapping
+// ErrNotSupported.
+func (c *Client) Metrics(ctx context.Context) (*MetricsResponse, error) {
+	var metrics MetricsResponse
+	err := c.do(ctx, "GET", "/api/v1/metrics", nil, &metrics)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("metrics: %w: %w", ErrNotSupported, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
@@ -0,0 +1,52 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/metrics" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"total_requests":42,"success_rate":0.98,"go_version":"go1.23"}`))
+	}))
+	defer server.Close()
+
+	metrics, err := NewWithEndpoint(server.URL).Metrics(context.Background())
+	if err != nil {
+		t.Fatalf("Metrics failed: %v", err)
+	}
+	if metrics.TotalRequests != 42 || metrics.SuccessRate != 0.98 || metrics.GoVersion != "go1.23" {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestMetricsNotSupported(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := NewWithEndpoint(server.URL).Metrics(context.Background())
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestMetricsContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := NewWithEndpoint(server.URL).Metrics(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
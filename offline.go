@@ -0,0 +1,200 @@
+package palletizer
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// offlineWarning is reported in the Warnings of every response built by
+// PackOffline, so that fallback results can be told apart from solves
+const offlineWarning = "packed offline by the client-side fallback heuristic; placements are not optimized"
+
+// PackOffline packs the request on the client with a simple layer-based shelf
+// heuristic, as a best-effort fallback for when the API is unreachable. It
+// does not match the quality of the server's solver: each pallet is filled
+// in horizontal layers of shelves, tallest cartons first, and Fragile,
+// PreferBottom, Zone, nesting and the support percentage are ignored. Every
+// pallet uses the request's PackingConstraints, which must be set.
+// Placed cartons are named "<id>_<n>" as the server names them and oriented
+// within Orientations, reported as "original" or "rotated". The response
+// carries a warning marking it as an offline result. An error is returned
+// if a carton can never be packed (see InfeasibleCartons).
+func PackOffline(req *PackingRequest) (*PackingResponse, error) {
+	start := time.Now()
+	limits := req.PackingConstraints
+	if err := limits.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid constraints: %w", err)
+	}
+	if ids := req.InfeasibleCartons(); len(ids) > 0 {
+		return nil, fmt.Errorf("cartons do not fit on the pallet: %s", strings.Join(ids, ", "))
+	}
+
+	var units []offlineUnit
+	for _, c := range req.Cartons {
+		for n := 1; n <= c.Quantity; n++ {
+			units = append(units, offlineUnit{id: c.ID + "_" + strconv.Itoa(n), carton: c})
+		}
+	}
+	slices.SortStableFunc(units, func(a, b offlineUnit) int {
+		return cmp.Or(
+			cmp.Compare(b.flattest().Height, a.flattest().Height),
+			cmp.Compare(b.carton.Length*b.carton.Width, a.carton.Length*a.carton.Width),
+		)
+	})
+
+	var packers []*shelfPacker
+	for _, u := range units {
+		placed := false
+		for _, p := range packers {
+			if placed = p.place(u); placed {
+				break
+			}
+		}
+		if !placed {
+			p := &shelfPacker{limits: limits}
+			if !p.place(u) {
+				return nil, fmt.Errorf("carton %s does not fit on an empty pallet", u.carton.ID)
+			}
+			packers = append(packers, p)
+		}
+	}
+
+	response := &PackingResponse{Warnings: []string{offlineWarning}}
+	capacity := limits.MaxLength * limits.MaxWidth * limits.MaxHeight
+	var utilization float64
+	for i, p := range packers {
+		pallet := p.pallet
+		pallet.PalletID = i + 1
+		pallet.CenterOfGravity = pallet.ComputeCOG()
+		volume := 0.0
+		for _, c := range pallet.Cartons {
+			volume += c.Dimensions.Length * c.Dimensions.Width * c.Dimensions.Height
+		}
+		pallet.UtilizationPercentage = volume / capacity * 100
+		utilization += pallet.UtilizationPercentage
+		response.Pallets = append(response.Pallets, pallet)
+	}
+	response.Summary = PackingSummary{
+		TotalPallets:       len(packers),
+		TotalCartonsPacked: len(units),
+		ComputationTimeMs:  int(time.Since(start).Milliseconds()),
+	}
+	if len(packers) > 0 {
+		response.Summary.AverageUtilization = utilization / float64(len(packers))
+	}
+	return response, nil
+}
+
+// offlineUnit is a single carton of a request line awaiting placement
+type offlineUnit struct {
+	id     string
+	carton Carton
+}
+
+// flattest returns the unit's orientation with the smallest height
+func (u offlineUnit) flattest() Dimensions {
+	return slices.MinFunc(u.carton.Orientations(), func(a, b Dimensions) int {
+		return cmp.Compare(a.Height, b.Height)
+	})
+}
+
+// orient returns the orientation of u that fits and is preferred by better
+// over every other fitting orientation
+func (u offlineUnit) orient(better func(a, b Dimensions) bool, fits func(Dimensions) bool) (Dimensions, bool) {
+	var best Dimensions
+	found := false
+	for _, d := range u.carton.Orientations() {
+		if fits(d) && (!found || better(d, best)) {
+			best, found = d, true
+		}
+	}
+	return best, found
+}
+
+// shelfPacker fills one pallet for PackOffline. Only the topmost layer is
+// open: it is filled with shelves running along X, stacked along Y, and a
+// new layer starts on top of it once a carton fits on no shelf.
+type shelfPacker struct {
+	limits      PackingConstraints
+	pallet      Pallet
+	layer       int
+	layerZ      float64 // bottom of the open layer
+	layerHeight float64 // height of the open layer (0 before the first carton)
+	shelfY      float64 // front of the open shelf
+	shelfDepth  float64 // extent of the open shelf along Y
+	x           float64 // next free position along the open shelf
+}
+
+// place puts u on the pallet if it fits, reporting whether it did
+func (p *shelfPacker) place(u offlineUnit) bool {
+	if p.pallet.TotalWeight+u.carton.Weight > p.limits.MaxWeight {
+		return false
+	}
+	limits := p.limits
+
+	// In the open layer, prefer the tallest orientation that fits, to fill
+	// the layer's height, and then the shortest along the shelf
+	tallest := func(a, b Dimensions) bool {
+		return a.Height > b.Height || (a.Height == b.Height && a.Length < b.Length)
+	}
+	if p.layerHeight > 0 {
+		if d, ok := u.orient(tallest, func(d Dimensions) bool {
+			return d.Height <= p.layerHeight && d.Width <= p.shelfDepth && p.x+d.Length <= limits.MaxLength
+		}); ok {
+			p.put(u, d)
+			return true
+		}
+		if d, ok := u.orient(tallest, func(d Dimensions) bool {
+			return d.Height <= p.layerHeight && p.shelfY+p.shelfDepth+d.Width <= limits.MaxWidth && d.Length <= limits.MaxLength
+		}); ok {
+			p.shelfY += p.shelfDepth
+			p.shelfDepth, p.x = d.Width, 0
+			p.put(u, d)
+			return true
+		}
+	}
+
+	// A new layer starts with the flattest orientation, largest footprint
+	// first, to keep the stack low and stable
+	top := p.layerZ + p.layerHeight
+	d, ok := u.orient(func(a, b Dimensions) bool {
+		return a.Height < b.Height || (a.Height == b.Height && a.Length*a.Width > b.Length*b.Width)
+	}, func(d Dimensions) bool {
+		return top+d.Height <= limits.MaxHeight && d.Length <= limits.MaxLength && d.Width <= limits.MaxWidth
+	})
+	if !ok {
+		return false
+	}
+	if p.layerHeight > 0 {
+		p.layer++
+	}
+	p.layerZ, p.layerHeight = top, d.Height
+	p.shelfY, p.shelfDepth, p.x = 0, d.Width, 0
+	p.put(u, d)
+	return true
+}
+
+// put places u in orientation d at the open position of the open shelf
+func (p *shelfPacker) put(u offlineUnit, d Dimensions) {
+	orientation := Orientation("rotated")
+	if d == (Dimensions{Length: u.carton.Length, Width: u.carton.Width, Height: u.carton.Height}) {
+		orientation = "original"
+	}
+	p.pallet.Cartons = append(p.pallet.Cartons, PlacedCarton{
+		CartonID:    u.id,
+		Position:    Point3D{X: p.x, Y: p.shelfY, Z: p.layerZ},
+		Dimensions:  d,
+		Orientation: orientation,
+		Weight:      u.carton.Weight,
+		Layer:       p.layer,
+		Zone:        u.carton.Zone,
+	})
+	p.x += d.Length
+	p.pallet.TotalWeight += u.carton.Weight
+	p.pallet.TotalHeight = math.Max(p.pallet.TotalHeight, p.layerZ+d.Height)
+}
@@ -0,0 +1,105 @@
+package palletizer
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestPackOffline(t *testing.T) {
+	req := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "BOX001", Length: 600, Width: 400, Height: 300, Weight: 10000, Quantity: 12},
+			{ID: "BOX002", Length: 300, Width: 200, Height: 400, Weight: 2000, Quantity: 10, AllowRotation: true},
+		},
+		PackingConstraints: PackingConstraints{MaxLength: 1200, MaxWidth: 800, MaxHeight: 1000, MaxWeight: 100000},
+	}
+
+	resp, err := PackOffline(req)
+	if err != nil {
+		t.Fatalf("PackOffline failed: %v", err)
+	}
+	if !slices.Contains(resp.Warnings, offlineWarning) {
+		t.Errorf("expected the offline warning, got %v", resp.Warnings)
+	}
+	if resp.Summary.TotalCartonsPacked != 22 || resp.Summary.TotalPallets != len(resp.Pallets) {
+		t.Errorf("unexpected summary: %+v", resp.Summary)
+	}
+
+	placed := 0
+	for _, p := range resp.Pallets {
+		placed += len(p.Cartons)
+		if ids := p.OutOfBoundsCartons(req.PackingConstraints); len(ids) > 0 {
+			t.Errorf("pallet %d: cartons out of bounds: %v", p.PalletID, ids)
+		}
+		if p.TotalWeight > req.PackingConstraints.MaxWeight {
+			t.Errorf("pallet %d: weight %g exceeds the limit", p.PalletID, p.TotalWeight)
+		}
+		for i, a := range p.Cartons {
+			for _, b := range p.Cartons[i+1:] {
+				zOverlap := math.Min(a.Position.Z+a.Dimensions.Height, b.Position.Z+b.Dimensions.Height) - math.Max(a.Position.Z, b.Position.Z)
+				if zOverlap > 0 && overlapArea(a, b) > 0 {
+					t.Errorf("pallet %d: %s overlaps %s", p.PalletID, a.CartonID, b.CartonID)
+				}
+			}
+		}
+	}
+	if placed != 22 {
+		t.Errorf("expected 22 placed cartons, got %d", placed)
+	}
+	if messages := ValidateDimensionInvariance(req, resp); len(messages) > 0 {
+		t.Errorf("expected dimensions to be preserved, got %v", messages)
+	}
+	// 12 cartons of 10 kg exceed one pallet's 100 kg limit
+	if len(resp.Pallets) < 2 {
+		t.Errorf("expected the weight limit to open a second pallet, got %d", len(resp.Pallets))
+	}
+}
+
+func TestPackOfflineInfeasible(t *testing.T) {
+	req := &PackingRequest{
+		Cartons:            []Carton{{ID: "HUGE", Length: 2000, Width: 400, Height: 300, Weight: 100, Quantity: 1}},
+		PackingConstraints: PackingConstraints{MaxLength: 1200, MaxWidth: 800, MaxHeight: 1000, MaxWeight: 100000},
+	}
+	if _, err := PackOffline(req); err == nil {
+		t.Error("expected error for a carton larger than the pallet")
+	}
+	if _, err := PackOffline(&PackingRequest{}); err == nil {
+		t.Error("expected error without constraints")
+	}
+}
+
+func TestWithOfflineFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	req := &PackingRequest{
+		Cartons:            []Carton{{ID: "BOX001", Length: 600, Width: 400, Height: 300, Weight: 1000, Quantity: 4}},
+		PackingConstraints: PackingConstraints{MaxLength: 1200, MaxWidth: 800, MaxHeight: 1000, MaxWeight: 100000},
+	}
+	if _, err := NewWithEndpoint(url).Pack(context.Background(), req); err == nil {
+		t.Fatal("expected network error without the fallback")
+	}
+
+	client := NewClient(WithEndpoint(url), WithOfflineFallback())
+	resp, err := client.Pack(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected the offline fallback, got %v", err)
+	}
+	if !resp.HasWarnings() || resp.Summary.TotalCartonsPacked != 4 {
+		t.Errorf("unexpected fallback response: %+v", resp)
+	}
+
+	status := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer status.Close()
+	client = NewClient(WithEndpoint(status.URL), WithOfflineFallback())
+	if _, err := client.Pack(context.Background(), req); err == nil {
+		t.Error("expected an error response not to fall back")
+	}
+}
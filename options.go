@@ -0,0 +1,255 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// WithEndpoint sets the base URL of the Palletizer API, e.g.
+// "http://localhost:8080"
+func WithEndpoint(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sends requests through httpClient instead of the default
+// client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP attempt, replacing the
+// default of 120 seconds. When combined with WithHTTPClient, the supplied
+// client is copied rather than modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithDefaultTimeout bounds each call whose context has no deadline to d,
+// including any retries. A deadline set on the context is used instead.
+// Unlike WithTimeout, which limits each HTTP attempt, this caps the call as
+// a whole.
+func WithDefaultTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.callTimeout = d
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request,
+// replacing the default "palletizer-go/<Version>"
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithMinTLSVersion sets the minimum TLS version (e.g. tls.VersionTLS13)
+// accepted by the default HTTP transport. The default is TLS 1.2. It has no
+// effect when a custom HTTP client is supplied.
+func WithMinTLSVersion(v uint16) Option {
+	return func(c *Client) {
+		c.minTLSVersion = v
+	}
+}
+
+// WithAPIKey authenticates every call by sending key in an
+// "Authorization: Bearer <key>" header
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithRouteTag sends tag in an X-Route-Tag header with every request, so a
+// multi-tenant backend can route calls to a particular solver pool. The tag
+// must not be empty: if it is, the client is still created but each call
+// fails with an error.
+func WithRouteTag(tag string) Option {
+	return func(c *Client) {
+		if strings.TrimSpace(tag) == "" {
+			c.configErr = errors.Join(c.configErr, errors.New("route tag must not be empty"))
+			return
+		}
+		c.routeTag = tag
+	}
+}
+
+// WithMethodOverride sends GET requests, such as Health and Metrics, as POST
+// requests carrying the original method in the named header, for gateways
+// that block GET. An empty header name uses "X-HTTP-Method-Override".
+func WithMethodOverride(header string) Option {
+	return func(c *Client) {
+		if header == "" {
+			header = "X-HTTP-Method-Override"
+		}
+		c.methodOverride = header
+	}
+}
+
+// WithLogger registers a hook called with the request and then the response
+// of every HTTP attempt, including retries, for debugging the exact JSON
+// exchanged. Bodies are truncated to 4 KiB; successful responses from
+// PackSummaryFirst are reported without a body. Without a hook no request
+// bodies are captured. Hooks, including the one installed by WithSlog, run
+// in the order they were registered.
+func WithLogger(hook func(event LogEvent)) Option {
+	return func(c *Client) {
+		c.logHooks = append(c.logHooks, func(_ context.Context, event LogEvent) { hook(event) })
+		c.logBodies = true
+	}
+}
+
+// WithSlog logs every HTTP attempt to logger, using the same hook point as
+// WithLogger: successful attempts at debug level and failed ones at error
+// level, with the endpoint, HTTP status, duration and carton count as
+// attributes. Calls that fail before a request is sent, such as on a rate
+// limit wait, are not logged.
+func WithSlog(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logHooks = append(c.logHooks, slogHook(logger))
+	}
+}
+
+// WithHealthCache caches successful Health results for ttl, so repeated
+// checks within that window do not reach the server. Use CheckHealth with
+// force set to bypass the cache.
+func WithHealthCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.healthCache = &healthCache{ttl: ttl}
+	}
+}
+
+// WithBeforeRequest registers a hook called with each outgoing HTTP request
+// just before it is sent. The hook may modify the request, for example to add
+// headers. A non-nil error aborts the call and is returned wrapped. Hooks run
+// once per HTTP attempt, in the order they were registered.
+func WithBeforeRequest(hook func(ctx context.Context, req *http.Request) error) Option {
+	return func(c *Client) {
+		c.beforeRequest = append(c.beforeRequest, hook)
+	}
+}
+
+// WithAfterResponse registers a hook called with each HTTP response and the
+// time taken to receive it, before the response is interpreted. The body has
+// already been read and is replayed to the hook, so the hook may read it. A
+// non-nil error fails the call and is returned wrapped. Hooks run once per
+// HTTP attempt, in the order they were registered.
+func WithAfterResponse(hook func(ctx context.Context, resp *http.Response, elapsed time.Duration) error) Option {
+	return func(c *Client) {
+		c.afterResponse = append(c.afterResponse, hook)
+	}
+}
+
+// WithDefaultConstraints sets the pallet constraints used for requests whose
+// PackingConstraints is the zero value; requests that set constraints are
+// sent unchanged. Every limit must be positive: if not, the client is still
+// created but each call fails with the validation error.
+func WithDefaultConstraints(constraints PackingConstraints) Option {
+	return func(c *Client) {
+		if err := constraints.Validate(); err != nil {
+			c.configErr = errors.Join(c.configErr, fmt.Errorf("invalid default constraints: %w", err))
+			return
+		}
+		c.defaultConstraints = &constraints
+	}
+}
+
+// WithOfflineFallback makes Pack pack the request locally with PackOffline
+// when the server cannot be reached, after any retries, so that an outage
+// degrades results rather than failing them. Error responses from the server
+// and cancelled calls are still returned as errors. Fallback results carry a
+// warning in PackingResponse.Warnings.
+func WithOfflineFallback() Option {
+	return func(c *Client) {
+		c.offlineFallback = true
+	}
+}
+
+// WithPollInterval sets how often Job.Wait polls an asynchronous job, and
+// the default interval for WaitForResultWithProgress. The default is one
+// second.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.pollInterval = interval
+	}
+}
+
+// WithRateLimit spaces outgoing HTTP requests to at most rps per second on
+// average, allowing bursts of up to burst requests. Each attempt, including
+// retries, waits for its turn. A call fails instead of waiting if its
+// context is cancelled or its deadline would pass first.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithRetry retries calls that fail with a 502, 503 or 504 response or a
+// network error, making up to maxAttempts attempts in total. Attempts are
+// spaced by exponential backoff with jitter starting from baseDelay, and
+// retrying stops early if the call's context is cancelled or its deadline
+// would pass before the next attempt. Other failures, such as a 400, are
+// never retried. A call that still fails returns a *RetryError holding the
+// error from every attempt. PackSummaryFirst is never retried.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.retry.maxAttempts = maxAttempts
+		c.retry.baseDelay = baseDelay
+	}
+}
+
+// WithRetryPolicy sets the total number of attempts allowed for responses
+// with specific HTTP status codes, e.g. {503: 5, 429: 2}. Listed codes are
+// retried even if they are not retried by default; unlisted codes use the
+// default retry behavior. Attempts are spaced by exponential backoff. Like
+// WithRetry, it does not apply to PackSummaryFirst.
+func WithRetryPolicy(attemptsByStatus map[int]int) Option {
+	return func(c *Client) {
+		c.retry.statusAttempts = maps.Clone(attemptsByStatus)
+	}
+}
+
+// WithNetworkRetries sets the total number of attempts allowed for calls
+// that fail without a response, such as connection errors, except for
+// PackSummaryFirst
+func WithNetworkRetries(maxAttempts int) Option {
+	return func(c *Client) {
+		c.retry.networkAttempts = maxAttempts
+	}
+}
+
+// WithAutoCorrelationID generates a UUID for every call and sends it in the
+// X-Correlation-ID header; retries of a call reuse its ID. The ID is
+// available from PackWithResponse.
+func WithAutoCorrelationID() Option {
+	return func(c *Client) {
+		if c.idGenerator == nil {
+			c.idGenerator = newUUID
+		}
+	}
+}
+
+// WithIDGenerator replaces the UUID generator used for correlation IDs, for
+// example to produce predictable IDs in tests. It implies
+// WithAutoCorrelationID.
+func WithIDGenerator(generate func() string) Option {
+	return func(c *Client) {
+		c.idGenerator = generate
+	}
+}
@@ -0,0 +1,362 @@
+package palletizer
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithMinTLSVersion(t *testing.T) {
+	minVersion := func(c *Client) uint16 {
+		transport, ok := c.httpClient.Transport.(*http.Transport)
+		if !ok || transport.TLSClientConfig == nil {
+			t.Fatal("expected default transport with TLS config")
+		}
+		return transport.TLSClientConfig.MinVersion
+	}
+
+	if v := minVersion(New()); v != tls.VersionTLS12 {
+		t.Errorf("expected default min TLS version %x, got %x", tls.VersionTLS12, v)
+	}
+	if v := minVersion(NewClient(WithMinTLSVersion(tls.VersionTLS13))); v != tls.VersionTLS13 {
+		t.Errorf("expected min TLS version %x, got %x", tls.VersionTLS13, v)
+	}
+}
+
+// roundTripFunc is a RoundTripper that is not an *http.Transport
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestReplacedDefaultTransport(t *testing.T) {
+	original := http.DefaultTransport
+	defer func() { http.DefaultTransport = original }()
+	calls := 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"status":"ok"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	health, err := New().Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if health.Status != "ok" || calls != 1 {
+		t.Errorf("expected the call to go through the replaced transport, got %+v after %d calls", health, calls)
+	}
+
+	client := NewClient(WithMinTLSVersion(tls.VersionTLS13))
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Error("expected a transport of its own honoring the minimum TLS version")
+	}
+}
+
+func TestRequestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test") != "hooked" {
+			t.Errorf("expected X-Test header set by hook, got %q", r.Header.Get("X-Test"))
+		}
+		w.Write([]byte(`{"summary":{"total_pallets":1}}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	client := NewClient(
+		WithBeforeRequest(func(ctx context.Context, req *http.Request) error {
+			order = append(order, "before")
+			req.Header.Set("X-Test", "hooked")
+			return nil
+		}),
+		WithAfterResponse(func(ctx context.Context, resp *http.Response, elapsed time.Duration) error {
+			order = append(order, "after")
+			body, _ := io.ReadAll(resp.Body)
+			if !strings.Contains(string(body), "total_pallets") {
+				t.Errorf("expected hook to see response body, got %q", body)
+			}
+			return nil
+		}),
+	)
+	client.baseURL = server.URL
+
+	response, err := client.Pack(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if response.Summary.TotalPallets != 1 {
+		t.Errorf("expected 1 pallet, got %d", response.Summary.TotalPallets)
+	}
+	if strings.Join(order, ",") != "before,after" {
+		t.Errorf("expected hooks before,after, got %v", order)
+	}
+
+	hookErr := errors.New("token expired")
+	failing := NewClient(WithBeforeRequest(func(ctx context.Context, req *http.Request) error {
+		return hookErr
+	}))
+	failing.baseURL = server.URL
+	if _, err := failing.Pack(context.Background(), &PackingRequest{}); !errors.Is(err, hookErr) {
+		t.Errorf("expected hook error, got %v", err)
+	}
+}
+
+func TestWithDefaultConstraints(t *testing.T) {
+	var received PackingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithDefaultConstraints(EURPallet()))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Quantity: 1}}}
+	if _, err := client.Pack(ctx, request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if received.PackingConstraints != EURPallet() {
+		t.Errorf("expected default constraints to be sent, got %+v", received.PackingConstraints)
+	}
+	if request.PackingConstraints != (PackingConstraints{}) {
+		t.Error("expected the caller's request to be left unchanged")
+	}
+
+	request.PackingConstraints = StandardPallet()
+	if _, err := client.Pack(ctx, request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if received.PackingConstraints != StandardPallet() {
+		t.Errorf("expected explicit constraints to be kept, got %+v", received.PackingConstraints)
+	}
+}
+
+func TestWithDefaultConstraintsInvalid(t *testing.T) {
+	client := NewClient(WithDefaultConstraints(PackingConstraints{MaxLength: 1200, MaxWidth: 800}))
+	_, err := client.Pack(context.Background(), &PackingRequest{})
+	if err == nil || !strings.Contains(err.Error(), "invalid default constraints: packing_constraints: max_height must be positive") {
+		t.Errorf("expected construction-time validation error, got %v", err)
+	}
+}
+
+func TestWithAPIKey(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"status":"ok","pallets":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("secret-key"))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	if _, err := client.Pack(ctx, &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if _, err := client.Health(ctx); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	for i, got := range auth {
+		if got != "Bearer secret-key" {
+			t.Errorf("request %d: expected bearer token, got %q", i, got)
+		}
+	}
+	if len(auth) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(auth))
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	client := NewClient(
+		WithEndpoint(server.URL),
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+		WithUserAgent("warehouse-planner/2.1"),
+	)
+
+	if _, err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if userAgent != "warehouse-planner/2.1" {
+		t.Errorf("expected custom User-Agent, got %q", userAgent)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("expected 5s timeout, got %v", client.httpClient.Timeout)
+	}
+	if httpClient.Timeout != time.Minute {
+		t.Error("expected the supplied HTTP client to be left unchanged")
+	}
+
+	if timeout := NewClient().httpClient.Timeout; timeout != 120*time.Second {
+		t.Errorf("expected default 120s timeout, got %v", timeout)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRateLimit(20, 1))
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.Pack(ctx, &PackingRequest{}); err != nil {
+			t.Fatalf("Pack failed: %v", err)
+		}
+	}
+	// The first request uses the burst; the other three wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+		t.Errorf("expected requests to be spaced, 4 took %v", elapsed)
+	}
+
+	slow := NewClient(WithEndpoint(server.URL), WithRateLimit(0.1, 1))
+	if _, err := slow.Pack(ctx, &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if _, err := slow.Pack(ctx, &PackingRequest{}); err == nil || !strings.HasPrefix(err.Error(), "rate limit: ") {
+		t.Errorf("expected rate limit error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the wait to respect the context, took %v", elapsed)
+	}
+}
+
+func TestWithMethodOverride(t *testing.T) {
+	var method, override string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, override = r.Method, r.Header.Get("X-HTTP-Method-Override")
+		w.Write([]byte(`{"status":"ok","pallets":[]}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	client := NewClient(WithEndpoint(server.URL), WithMethodOverride(""))
+	if _, err := client.Health(ctx); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if method != "POST" || override != "GET" {
+		t.Errorf("expected POST with GET override, got %s %q", method, override)
+	}
+
+	if _, err := client.Pack(ctx, &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if method != "POST" || override != "" {
+		t.Errorf("expected a plain POST for Pack, got %s %q", method, override)
+	}
+
+	if _, err := NewWithEndpoint(server.URL).Health(ctx); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if method != "GET" || override != "" {
+		t.Errorf("expected a plain GET by default, got %s %q", method, override)
+	}
+}
+
+func TestWithDefaultTimeout(t *testing.T) {
+	delay := 100 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			w.Write([]byte(`{"pallets":[]}`))
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithDefaultTimeout(20*time.Millisecond))
+
+	t.Run("no deadline", func(t *testing.T) {
+		start := time.Now()
+		_, err := client.Pack(context.Background(), &PackingRequest{})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed >= delay {
+			t.Errorf("expected the default timeout to cut the call short, took %v", elapsed)
+		}
+	})
+
+	t.Run("caller deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := client.Pack(ctx, &PackingRequest{}); err != nil {
+			t.Errorf("expected the caller's deadline to apply instead, got %v", err)
+		}
+	})
+}
+
+func TestWithRouteTag(t *testing.T) {
+	var tags []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags = append(tags, r.Header.Get("X-Route-Tag"))
+		_, present := r.Header["X-Route-Tag"]
+		if !present {
+			tags[len(tags)-1] = "<absent>"
+		}
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	if _, err := NewClient(WithEndpoint(server.URL), WithRouteTag("heavy")).Pack(ctx, &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if _, err := NewWithEndpoint(server.URL).Pack(ctx, &PackingRequest{}); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if fmt.Sprint(tags) != "[heavy <absent>]" {
+		t.Errorf("expected tag then no header, got %v", tags)
+	}
+
+	_, err := NewClient(WithEndpoint(server.URL), WithRouteTag(" ")).Pack(ctx, &PackingRequest{})
+	if err == nil || err.Error() != "route tag must not be empty" {
+		t.Errorf("expected empty tag error, got %v", err)
+	}
+	if len(tags) != 2 {
+		t.Error("expected no request to be sent with an invalid tag")
+	}
+}
+
+func TestInvalidOptionsAccumulate(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewClient(WithRouteTag(""), WithRouteTag("heavy")).Pack(ctx, &PackingRequest{})
+	if err == nil || err.Error() != "route tag must not be empty" {
+		t.Errorf("expected a later valid option to keep the error, got %v", err)
+	}
+
+	_, err = NewClient(WithRouteTag(""), WithDefaultConstraints(PackingConstraints{})).Pack(ctx, &PackingRequest{})
+	if err == nil || !strings.Contains(err.Error(), "route tag must not be empty") ||
+		!strings.Contains(err.Error(), "invalid default constraints") {
+		t.Errorf("expected both option errors, got %v", err)
+	}
+}
@@ -0,0 +1,724 @@
+package palletizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// positionTolerance is the distance in millimeters within which two
+// coordinates from the server are considered equal
+const positionTolerance = 0.5
+
+// FootprintPolygon returns the convex hull of the footprint corners of every
+// carton in the base layer (layer 0). Z is ignored and every returned point
+// has Z=0. The ring is closed: the first point is repeated at the end.
+// It returns nil for a pallet with no base-layer cartons.
+func (p Pallet) FootprintPolygon() []Point3D {
+	var points []Point3D
+	for _, c := range p.Cartons {
+		if c.Layer != 0 {
+			continue
+		}
+		x0, y0 := c.Position.X, c.Position.Y
+		x1, y1 := x0+c.Dimensions.Length, y0+c.Dimensions.Width
+		points = append(points,
+			Point3D{X: x0, Y: y0},
+			Point3D{X: x1, Y: y0},
+			Point3D{X: x1, Y: y1},
+			Point3D{X: x0, Y: y1},
+		)
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	hull := convexHull(points)
+	return append(hull, hull[0])
+}
+
+// ComputeCOG returns the center of gravity of the pallet computed from its
+// placed cartons: the weight-weighted centroid of each carton's center.
+// Comparing it to CenterOfGravity reveals disagreement with the server.
+// It returns the zero point when the cartons carry no weight.
+func (p Pallet) ComputeCOG() Point3D {
+	var cog Point3D
+	var total float64
+	for _, c := range p.Cartons {
+		center := c.Center()
+		cog.X += center.X * c.Weight
+		cog.Y += center.Y * c.Weight
+		cog.Z += center.Z * c.Weight
+		total += c.Weight
+	}
+	if total == 0 {
+		return Point3D{}
+	}
+	cog.X /= total
+	cog.Y /= total
+	cog.Z /= total
+	return cog
+}
+
+// Center returns the geometric center of the placed carton
+func (c PlacedCarton) Center() Point3D {
+	return Point3D{
+		X: c.Position.X + c.Dimensions.Length/2,
+		Y: c.Position.Y + c.Dimensions.Width/2,
+		Z: c.Position.Z + c.Dimensions.Height/2,
+	}
+}
+
+// EstimateBuildSeconds estimates how long it takes to build the pallet by
+// hand: secondsPerCarton for every placed carton, plus an optional
+// layerChangeSeconds penalty for each transition to a new layer.
+func (p Pallet) EstimateBuildSeconds(secondsPerCarton float64, layerChangeSeconds ...float64) float64 {
+	seconds := float64(len(p.Cartons)) * secondsPerCarton
+	if layers := p.LayerCount(); len(layerChangeSeconds) > 0 && layers > 1 {
+		seconds += float64(layers-1) * layerChangeSeconds[0]
+	}
+	return seconds
+}
+
+// LayerCount returns the number of distinct layers on the pallet
+func (p Pallet) LayerCount() int {
+	layers := make(map[int]bool)
+	for _, c := range p.Cartons {
+		layers[c.Layer] = true
+	}
+	return len(layers)
+}
+
+// WeightByLayer returns the total weight in grams of the cartons on each
+// layer, keyed by layer number. A pallet with no cartons returns an empty map.
+func (p Pallet) WeightByLayer() map[int]float64 {
+	weights := make(map[int]float64)
+	for _, c := range p.Cartons {
+		weights[c.Layer] += c.Weight
+	}
+	return weights
+}
+
+// Footprint returns the length and width in millimeters of the area the
+// pallet's cartons occupy, measured from the pallet origin to the farthest
+// carton edge along X and Y
+func (p Pallet) Footprint() (length, width float64) {
+	for _, c := range p.Cartons {
+		length = math.Max(length, c.Position.X+c.Dimensions.Length)
+		width = math.Max(width, c.Position.Y+c.Dimensions.Width)
+	}
+	return length, width
+}
+
+// Density returns the pallet's total weight divided by its loaded volume (the
+// footprint times TotalHeight), in grams per cubic millimeter. It returns 0
+// for an empty pallet.
+func (p Pallet) Density() float64 {
+	length, width := p.Footprint()
+	volume := length * width * p.TotalHeight
+	if volume <= 0 {
+		return 0
+	}
+	return p.TotalWeight / volume
+}
+
+// FullLayerRatio returns the fraction of the pallet's layers, from 0 to 1,
+// whose cartons tile a complete rectangle with no gaps, as an automated
+// layer former requires. A layer counts as full when its cartons' footprints
+// cover at least 99% of their bounding rectangle. It returns 0 for an empty
+// pallet.
+func (p Pallet) FullLayerRatio() float64 {
+	type bounds struct{ x0, y0, x1, y1, area float64 }
+	layers := make(map[int]*bounds)
+	for _, c := range p.Cartons {
+		b, ok := layers[c.Layer]
+		if !ok {
+			b = &bounds{x0: math.Inf(1), y0: math.Inf(1), x1: math.Inf(-1), y1: math.Inf(-1)}
+			layers[c.Layer] = b
+		}
+		b.x0 = math.Min(b.x0, c.Position.X)
+		b.y0 = math.Min(b.y0, c.Position.Y)
+		b.x1 = math.Max(b.x1, c.Position.X+c.Dimensions.Length)
+		b.y1 = math.Max(b.y1, c.Position.Y+c.Dimensions.Width)
+		b.area += c.Dimensions.Length * c.Dimensions.Width
+	}
+	if len(layers) == 0 {
+		return 0
+	}
+
+	full := 0
+	for _, b := range layers {
+		if rect := (b.x1 - b.x0) * (b.y1 - b.y0); rect > 0 && b.area >= 0.99*rect {
+			full++
+		}
+	}
+	return float64(full) / float64(len(layers))
+}
+
+// LoadAboveEachCarton returns the weight in grams resting on each placed
+// carton, keyed by carton ID. The weight of every carton, together with the
+// load it bears itself, is passed down to the cartons directly beneath it in
+// proportion to their overlapping footprint area.
+func (p Pallet) LoadAboveEachCarton() map[string]float64 {
+	order := make([]int, len(p.Cartons))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return p.Cartons[order[a]].Position.Z > p.Cartons[order[b]].Position.Z
+	})
+
+	load := make([]float64, len(p.Cartons))
+	for _, i := range order {
+		top := p.Cartons[i]
+		var supports []int
+		var overlaps []float64
+		var total float64
+		for j, below := range p.Cartons {
+			if j == i {
+				continue
+			}
+			if area := supportArea(top, below); area > 0 {
+				supports = append(supports, j)
+				overlaps = append(overlaps, area)
+				total += area
+			}
+		}
+		for k, j := range supports {
+			load[j] += (top.Weight + load[i]) * overlaps[k] / total
+		}
+	}
+
+	result := make(map[string]float64, len(p.Cartons))
+	for i, c := range p.Cartons {
+		result[c.CartonID] = load[i]
+	}
+	return result
+}
+
+// CrushViolations returns the IDs of placed cartons whose borne load exceeds
+// the MaxLoadBearing of the request carton they originate from. Cartons
+// without a MaxLoadBearing are never reported.
+func (p Pallet) CrushViolations(req *PackingRequest) []string {
+	load := p.LoadAboveEachCarton()
+	var violations []string
+	for _, c := range p.Cartons {
+		original, ok := req.cartonFor(c.CartonID)
+		if !ok || original.MaxLoadBearing <= 0 {
+			continue
+		}
+		if load[c.CartonID] > original.MaxLoadBearing {
+			violations = append(violations, c.CartonID)
+		}
+	}
+	return violations
+}
+
+// MaxStackingPressure returns the highest load-bearing pressure on any carton,
+// in grams per square millimeter, and the ID of that carton. Pressure is the
+// load from LoadAboveEachCarton divided by the carton's footprint area. It
+// returns 0 and "" when no carton bears any load.
+func (p Pallet) MaxStackingPressure() (float64, string) {
+	load := p.LoadAboveEachCarton()
+	var maxPressure float64
+	var maxID string
+	for _, c := range p.Cartons {
+		area := c.Dimensions.Length * c.Dimensions.Width
+		if area <= 0 {
+			continue
+		}
+		if pressure := load[c.CartonID] / area; pressure > maxPressure {
+			maxPressure, maxID = pressure, c.CartonID
+		}
+	}
+	return maxPressure, maxID
+}
+
+// GrippableCartons returns the IDs of cartons a gripper can pick from above:
+// nothing rests on the carton, and it has at least minClearanceMM of free
+// space on both faces of one horizontal axis (both X faces or both Y faces).
+// Faces with no neighboring carton have unlimited clearance.
+func (p Pallet) GrippableCartons(minClearanceMM float64) []string {
+	var ids []string
+	for i, c := range p.Cartons {
+		if !p.topExposed(i) {
+			continue
+		}
+		left, right, front, back := p.clearances(i)
+		if (left >= minClearanceMM && right >= minClearanceMM) || (front >= minClearanceMM && back >= minClearanceMM) {
+			ids = append(ids, c.CartonID)
+		}
+	}
+	return ids
+}
+
+// topExposed reports whether no other carton lies above the footprint of
+// carton i
+func (p Pallet) topExposed(i int) bool {
+	c := p.Cartons[i]
+	top := c.Position.Z + c.Dimensions.Height
+	for j, other := range p.Cartons {
+		if j != i && other.Position.Z >= top-positionTolerance && overlapArea(c, other) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// clearances returns the free distance from each vertical face of carton i
+// (-X, +X, -Y, +Y) to the nearest carton facing it. A face with no carton
+// facing it has infinite clearance.
+func (p Pallet) clearances(i int) (left, right, front, back float64) {
+	c := p.Cartons[i]
+	left, right, front, back = math.Inf(1), math.Inf(1), math.Inf(1), math.Inf(1)
+	overlaps := func(a0, a1, b0, b1 float64) bool {
+		return math.Min(a1, b1)-math.Max(a0, b0) > positionTolerance
+	}
+	for j, o := range p.Cartons {
+		if j == i || !overlaps(c.Position.Z, c.Position.Z+c.Dimensions.Height, o.Position.Z, o.Position.Z+o.Dimensions.Height) {
+			continue
+		}
+		if overlaps(c.Position.Y, c.Position.Y+c.Dimensions.Width, o.Position.Y, o.Position.Y+o.Dimensions.Width) {
+			if gap := c.Position.X - (o.Position.X + o.Dimensions.Length); gap > -positionTolerance {
+				left = math.Min(left, math.Max(gap, 0))
+			}
+			if gap := o.Position.X - (c.Position.X + c.Dimensions.Length); gap > -positionTolerance {
+				right = math.Min(right, math.Max(gap, 0))
+			}
+		}
+		if overlaps(c.Position.X, c.Position.X+c.Dimensions.Length, o.Position.X, o.Position.X+o.Dimensions.Length) {
+			if gap := c.Position.Y - (o.Position.Y + o.Dimensions.Width); gap > -positionTolerance {
+				front = math.Min(front, math.Max(gap, 0))
+			}
+			if gap := o.Position.Y - (c.Position.Y + c.Dimensions.Width); gap > -positionTolerance {
+				back = math.Min(back, math.Max(gap, 0))
+			}
+		}
+	}
+	return left, right, front, back
+}
+
+// ExposedSurfaceArea returns the area in square millimeters of the faces of
+// the carton with the given ID that are not in contact with a neighboring
+// carton or, for a carton on the base, with the pallet deck. It returns 0 if
+// no carton has that ID.
+func (p Pallet) ExposedSurfaceArea(cartonID string) float64 {
+	idx := -1
+	for i, c := range p.Cartons {
+		if c.CartonID == cartonID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return 0
+	}
+
+	c := p.Cartons[idx]
+	l, w, h := c.Dimensions.Length, c.Dimensions.Width, c.Dimensions.Height
+	exposed := 2 * (l*w + l*h + w*h)
+	if c.Position.Z <= positionTolerance {
+		exposed -= l * w
+	}
+
+	// span returns the overlap of [a0, a1) and [b0, b1)
+	span := func(a0, a1, b0, b1 float64) float64 {
+		return math.Max(0, math.Min(a1, b1)-math.Max(a0, b0))
+	}
+	touching := func(a, b float64) bool { return math.Abs(a-b) <= positionTolerance }
+
+	x0, y0, z0 := c.Position.X, c.Position.Y, c.Position.Z
+	x1, y1, z1 := x0+l, y0+w, z0+h
+	for j, o := range p.Cartons {
+		if j == idx {
+			continue
+		}
+		ox0, oy0, oz0 := o.Position.X, o.Position.Y, o.Position.Z
+		ox1, oy1, oz1 := ox0+o.Dimensions.Length, oy0+o.Dimensions.Width, oz0+o.Dimensions.Height
+
+		if touching(z1, oz0) || touching(z0, oz1) {
+			exposed -= span(x0, x1, ox0, ox1) * span(y0, y1, oy0, oy1)
+		}
+		if touching(x1, ox0) || touching(x0, ox1) {
+			exposed -= span(y0, y1, oy0, oy1) * span(z0, z1, oz0, oz1)
+		}
+		if touching(y1, oy0) || touching(y0, oy1) {
+			exposed -= span(x0, x1, ox0, ox1) * span(z0, z1, oz0, oz1)
+		}
+	}
+	return math.Max(exposed, 0)
+}
+
+// PlacementFingerprint returns a hash identifying the pallet's layout. It
+// covers each carton's ID, position, dimensions and orientation, with
+// coordinates rounded to the nearest millimeter, and is independent of the
+// order of Cartons.
+func (p Pallet) PlacementFingerprint() string {
+	q := func(v float64) int64 { return int64(math.Round(v)) }
+	tuples := make([]string, len(p.Cartons))
+	for i, c := range p.Cartons {
+		tuples[i] = fmt.Sprintf("%s|%d,%d,%d|%d,%d,%d|%s",
+			c.CartonID,
+			q(c.Position.X), q(c.Position.Y), q(c.Position.Z),
+			q(c.Dimensions.Length), q(c.Dimensions.Width), q(c.Dimensions.Height),
+			c.Orientation)
+	}
+	sort.Strings(tuples)
+
+	sum := sha256.Sum256([]byte(strings.Join(tuples, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// SolidAreaAtHeight returns the footprint area in square millimeters of the
+// cartons occupying height z. A carton occupies heights from the bottom of its
+// Z-range up to, but not including, its top.
+func (p Pallet) SolidAreaAtHeight(z float64) float64 {
+	var area float64
+	for _, c := range p.Cartons {
+		if z >= c.Position.Z && z < c.Position.Z+c.Dimensions.Height {
+			area += c.Dimensions.Length * c.Dimensions.Width
+		}
+	}
+	return area
+}
+
+// HeavyOnBottomScore returns the Pearson correlation between carton weight
+// and depth (the negated height of each carton's center), from -1 to 1. A
+// score near 1 means heavy cartons sit low on the pallet. It returns 0 when
+// there are fewer than two cartons or when weights or heights do not vary.
+func (p Pallet) HeavyOnBottomScore() float64 {
+	n := float64(len(p.Cartons))
+	if n < 2 {
+		return 0
+	}
+
+	var meanW, meanD float64
+	for _, c := range p.Cartons {
+		meanW += c.Weight
+		meanD -= c.Center().Z
+	}
+	meanW /= n
+	meanD /= n
+
+	var cov, varW, varD float64
+	for _, c := range p.Cartons {
+		dw := c.Weight - meanW
+		dd := -c.Center().Z - meanD
+		cov += dw * dd
+		varW += dw * dw
+		varD += dd * dd
+	}
+	if varW == 0 || varD == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varW*varD)
+}
+
+// LayerPatternType classifies how the given layer is stacked on the layer
+// below it: "column" when every carton sits exactly on the footprint of a
+// carton below, "brick" when none does (seams are offset, interlocking the
+// layers), and "mixed" otherwise. It returns "" for the base layer and for
+// layers that do not exist.
+func (p Pallet) LayerPatternType(layer int) string {
+	if layer <= 0 {
+		return ""
+	}
+
+	var current, below []PlacedCarton
+	for _, c := range p.Cartons {
+		switch c.Layer {
+		case layer:
+			current = append(current, c)
+		case layer - 1:
+			below = append(below, c)
+		}
+	}
+	if len(current) == 0 || len(below) == 0 {
+		return ""
+	}
+
+	aligned := 0
+	for _, c := range current {
+		for _, b := range below {
+			if sameFootprint(c, b) {
+				aligned++
+				break
+			}
+		}
+	}
+
+	switch aligned {
+	case len(current):
+		return "column"
+	case 0:
+		return "brick"
+	default:
+		return "mixed"
+	}
+}
+
+// AccessibilityRank ranks the pallet's cartons by how easy they are to
+// reach, keyed by placed carton ID with 1 for the most accessible. Each
+// carton scores the sum of its top height relative to the pallet's tallest
+// carton and its closeness to the nearest footprint edge, both from 0 to 1,
+// so a carton on top at the edge scores highest. Ties are ranked by ID.
+func (p Pallet) AccessibilityRank() map[string]int {
+	length, width := p.Footprint()
+	var top float64
+	for _, c := range p.Cartons {
+		top = math.Max(top, c.Position.Z+c.Dimensions.Height)
+	}
+	maxInset := math.Min(length, width) / 2
+
+	scores := make(map[string]float64, len(p.Cartons))
+	ids := make([]string, 0, len(p.Cartons))
+	for _, c := range p.Cartons {
+		var score float64
+		if top > 0 {
+			score += (c.Position.Z + c.Dimensions.Height) / top
+		}
+		if maxInset > 0 {
+			inset := min(c.Position.X, c.Position.Y,
+				length-(c.Position.X+c.Dimensions.Length), width-(c.Position.Y+c.Dimensions.Width))
+			score += 1 - math.Max(inset, 0)/maxInset
+		}
+		scores[c.CartonID] = score
+		ids = append(ids, c.CartonID)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	ranks := make(map[string]int, len(ids))
+	for i, id := range ids {
+		ranks[id] = i + 1
+	}
+	return ranks
+}
+
+// ZoneCohesion measures how well cartons bound for the same destination
+// zone are clustered, from 0 to 1. For each carton with a Zone, the nearest
+// other zoned carton (by center distance) is found; the result is the
+// fraction of cartons whose nearest neighbor shares their zone. When several
+// neighbors are equally near, the carton counts by the fraction of them in
+// its zone. A pallet with fewer than two zoned cartons scores 1.
+func (p Pallet) ZoneCohesion() float64 {
+	var zoned []PlacedCarton
+	for _, c := range p.Cartons {
+		if c.Zone != "" {
+			zoned = append(zoned, c)
+		}
+	}
+	if len(zoned) < 2 {
+		return 1
+	}
+
+	var score float64
+	for i, c := range zoned {
+		best := math.Inf(1)
+		for j, other := range zoned {
+			if i != j {
+				best = math.Min(best, distance(c.Center(), other.Center()))
+			}
+		}
+		nearest, same := 0, 0
+		for j, other := range zoned {
+			if i != j && distance(c.Center(), other.Center()) <= best+positionTolerance {
+				nearest++
+				if other.Zone == c.Zone {
+					same++
+				}
+			}
+		}
+		score += float64(same) / float64(nearest)
+	}
+	return score / float64(len(zoned))
+}
+
+// OutOfBoundsCartons returns the IDs of placed cartons with a negative
+// position coordinate or an extent beyond the pallet constraints' MaxLength,
+// MaxWidth or MaxHeight. The upper bounds allow positionTolerance for
+// rounding in the server's coordinates.
+func (p Pallet) OutOfBoundsCartons(c PackingConstraints) []string {
+	var ids []string
+	for _, pc := range p.Cartons {
+		if pc.Position.X < 0 || pc.Position.Y < 0 || pc.Position.Z < 0 ||
+			pc.Position.X+pc.Dimensions.Length > c.MaxLength+positionTolerance ||
+			pc.Position.Y+pc.Dimensions.Width > c.MaxWidth+positionTolerance ||
+			pc.Position.Z+pc.Dimensions.Height > c.MaxHeight+positionTolerance {
+			ids = append(ids, pc.CartonID)
+		}
+	}
+	return ids
+}
+
+// BalanceOffsets returns how far the pallet's center of gravity, as computed
+// by ComputeCOG, sits from the center of its Footprint along each horizontal
+// axis. lateral is the offset along Y divided by half the footprint width and
+// longitudinal the offset along X divided by half its length, so each ranges
+// from -1 to 1 with 0 perfectly balanced. Both are 0 for an empty pallet.
+func (p Pallet) BalanceOffsets() (lateral, longitudinal float64) {
+	length, width := p.Footprint()
+	if length <= 0 || width <= 0 {
+		return 0, 0
+	}
+	cog := p.ComputeCOG()
+	if cog == (Point3D{}) {
+		return 0, 0
+	}
+	return (cog.Y - width/2) / (width / 2), (cog.X - length/2) / (length / 2)
+}
+
+// IsStable reports whether the pallet's reported CenterOfGravity is low and
+// central enough to move safely. maxCoGHeightRatio caps the CoG height as a
+// fraction of TotalHeight: 0.5 rejects a CoG above mid-stack.
+// maxHorizontalOffsetRatio caps the distance of the CoG from the center of
+// the Footprint along each horizontal axis, as a fraction of half the
+// footprint's width (along Y) or length (along X): 0 demands a perfectly
+// centered CoG and 1 allows it anywhere over the footprint. An empty pallet
+// is stable.
+func (p Pallet) IsStable(maxCoGHeightRatio, maxHorizontalOffsetRatio float64) bool {
+	length, width := p.Footprint()
+	if length <= 0 || width <= 0 || p.TotalHeight <= 0 {
+		return true
+	}
+	cog := p.CenterOfGravity
+	if cog.Z > maxCoGHeightRatio*p.TotalHeight {
+		return false
+	}
+	return math.Abs(cog.X-length/2) <= maxHorizontalOffsetRatio*length/2 &&
+		math.Abs(cog.Y-width/2) <= maxHorizontalOffsetRatio*width/2
+}
+
+// UsedOrientations returns the distinct orientations of the pallet's
+// cartons, sorted
+func (p Pallet) UsedOrientations() []Orientation {
+	var orientations []Orientation
+	for _, c := range p.Cartons {
+		if !slices.Contains(orientations, c.Orientation) {
+			orientations = append(orientations, c.Orientation)
+		}
+	}
+	slices.Sort(orientations)
+	return orientations
+}
+
+// sameFootprint reports whether a and b cover the same rectangle in X and Y
+func sameFootprint(a, b PlacedCarton) bool {
+	return math.Abs(a.Position.X-b.Position.X) <= positionTolerance &&
+		math.Abs(a.Position.Y-b.Position.Y) <= positionTolerance &&
+		math.Abs(a.Dimensions.Length-b.Dimensions.Length) <= positionTolerance &&
+		math.Abs(a.Dimensions.Width-b.Dimensions.Width) <= positionTolerance
+}
+
+// loadVolume returns the total volume in cubic millimeters of the placed
+// cartons
+func (p Pallet) loadVolume() float64 {
+	var volume float64
+	for _, c := range p.Cartons {
+		volume += c.Dimensions.Length * c.Dimensions.Width * c.Dimensions.Height
+	}
+	return volume
+}
+
+// supportArea returns the footprint area over which below directly supports
+// top: their overlap if below's top face meets top's bottom face, else 0
+func supportArea(top, below PlacedCarton) float64 {
+	if math.Abs(below.Position.Z+below.Dimensions.Height-top.Position.Z) > positionTolerance {
+		return 0
+	}
+	return overlapArea(top, below)
+}
+
+// overlapArea returns the area in square millimeters of the intersection of
+// the footprints of a and b
+func overlapArea(a, b PlacedCarton) float64 {
+	dx := math.Min(a.Position.X+a.Dimensions.Length, b.Position.X+b.Dimensions.Length) - math.Max(a.Position.X, b.Position.X)
+	dy := math.Min(a.Position.Y+a.Dimensions.Width, b.Position.Y+b.Dimensions.Width) - math.Max(a.Position.Y, b.Position.Y)
+	if dx <= 0 || dy <= 0 {
+		return 0
+	}
+	return dx * dy
+}
+
+// skuOf recovers the request carton ID from a placed carton ID. The server
+// names placed cartons "<id>_<n>", so a trailing numeric suffix is removed.
+func skuOf(placedID string) string {
+	i := strings.LastIndexByte(placedID, '_')
+	if i <= 0 || i == len(placedID)-1 {
+		return placedID
+	}
+	for _, r := range placedID[i+1:] {
+		if r < '0' || r > '9' {
+			return placedID
+		}
+	}
+	return placedID[:i]
+}
+
+// cartonFor returns the request carton a placed carton ID originates from
+func (r *PackingRequest) cartonFor(placedID string) (Carton, bool) {
+	sku := skuOf(placedID)
+	for _, id := range []string{placedID, sku} {
+		for _, c := range r.Cartons {
+			if c.ID == id {
+				return c, true
+			}
+		}
+	}
+	return Carton{}, false
+}
+
+// Quantize returns a copy of the carton with its position and dimensions
+// rounded to the nearest multiple of gridMM, so that placements from
+// different solves can be compared without floating-point jitter. Rounding
+// is lossy: quantized placements are meant for comparison and should not be
+// submitted anywhere. A gridMM of zero or less returns the carton unchanged.
+func (c PlacedCarton) Quantize(gridMM float64) PlacedCarton {
+	if gridMM <= 0 {
+		return c
+	}
+	snap := func(v float64) float64 { return math.Round(v/gridMM) * gridMM }
+	c.Position = Point3D{X: snap(c.Position.X), Y: snap(c.Position.Y), Z: snap(c.Position.Z)}
+	c.Dimensions = Dimensions{Length: snap(c.Dimensions.Length), Width: snap(c.Dimensions.Width), Height: snap(c.Dimensions.Height)}
+	return c
+}
+
+// convexHull returns the convex hull of points in counter-clockwise order
+// using Andrew's monotone chain algorithm. Only X and Y are considered.
+func convexHull(points []Point3D) []Point3D {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].X != points[j].X {
+			return points[i].X < points[j].X
+		}
+		return points[i].Y < points[j].Y
+	})
+
+	cross := func(o, a, b Point3D) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+
+	hull := make([]Point3D, 0, 2*len(points))
+	for _, pt := range points {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], pt) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+	lower := len(hull) + 1
+	for i := len(points) - 2; i >= 0; i-- {
+		pt := points[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], pt) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+	return hull[:len(hull)-1]
+}
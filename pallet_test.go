@@ -0,0 +1,543 @@
+package palletizer
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"testing"
+)
+
+func TestFootprintPolygon(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 400, Width: 300, Height: 200}},
+			{CartonID: "A_2", Position: Point3D{X: 400, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 400, Width: 600, Height: 200}},
+			{CartonID: "A_3", Position: Point3D{X: 0, Y: 0, Z: 200}, Dimensions: Dimensions{Length: 1000, Width: 1000, Height: 200}, Layer: 1},
+		},
+	}
+
+	polygon := pallet.FootprintPolygon()
+	expected := []Point3D{
+		{X: 0, Y: 0}, {X: 800, Y: 0}, {X: 800, Y: 600}, {X: 400, Y: 600}, {X: 0, Y: 300}, {X: 0, Y: 0},
+	}
+	if len(polygon) != len(expected) {
+		t.Fatalf("expected %d points, got %d: %v", len(expected), len(polygon), polygon)
+	}
+	for i := range expected {
+		if polygon[i] != expected[i] {
+			t.Errorf("point %d: expected %v, got %v", i, expected[i], polygon[i])
+		}
+	}
+
+	if got := (Pallet{}).FootprintPolygon(); got != nil {
+		t.Errorf("expected nil polygon for empty pallet, got %v", got)
+	}
+}
+
+func TestComputeCOG(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 200}, Weight: 3000},
+			{CartonID: "A_2", Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 200}, Weight: 1000},
+		},
+	}
+
+	cog := pallet.ComputeCOG()
+	expected := Point3D{X: 150, Y: 100, Z: 100}
+	if cog != expected {
+		t.Errorf("expected COG %v, got %v", expected, cog)
+	}
+
+	if cog := (Pallet{}).ComputeCOG(); cog != (Point3D{}) {
+		t.Errorf("expected zero COG for empty pallet, got %v", cog)
+	}
+}
+
+func TestEstimateBuildSeconds(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{{Layer: 0}, {Layer: 0}, {Layer: 1}, {Layer: 2}},
+	}
+
+	if got := pallet.EstimateBuildSeconds(10); got != 40 {
+		t.Errorf("expected 40 seconds, got %f", got)
+	}
+	if got := pallet.EstimateBuildSeconds(10, 30); got != 100 {
+		t.Errorf("expected 100 seconds with layer penalty, got %f", got)
+	}
+}
+
+func TestLoadAboveEachCarton(t *testing.T) {
+	// Two cartons side by side with a third bridging them, and a fourth on top.
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}, Weight: 1000},
+			{CartonID: "A_2", Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}, Weight: 1000},
+			{CartonID: "B_1", Position: Point3D{X: 100, Y: 0, Z: 100}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}, Weight: 2000},
+			{CartonID: "C_1", Position: Point3D{X: 100, Y: 0, Z: 200}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}, Weight: 500},
+		},
+	}
+
+	load := pallet.LoadAboveEachCarton()
+	expected := map[string]float64{"A_1": 1250, "A_2": 1250, "B_1": 500, "C_1": 0}
+	for id, want := range expected {
+		if load[id] != want {
+			t.Errorf("%s: expected load %f, got %f", id, want, load[id])
+		}
+	}
+
+	request := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "A", MaxLoadBearing: 1000},
+			{ID: "B", MaxLoadBearing: 1000},
+			{ID: "C"},
+		},
+	}
+	violations := pallet.CrushViolations(request)
+	if len(violations) != 2 || violations[0] != "A_1" || violations[1] != "A_2" {
+		t.Errorf("expected violations [A_1 A_2], got %v", violations)
+	}
+}
+
+func TestSKUOf(t *testing.T) {
+	tests := map[string]string{
+		"BOX001_1":  "BOX001",
+		"BOX001_12": "BOX001",
+		"BOX001":    "BOX001",
+		"MY_BOX":    "MY_BOX",
+		"MY_BOX_3":  "MY_BOX",
+		"BOX_":      "BOX_",
+	}
+	for input, expected := range tests {
+		if got := skuOf(input); got != expected {
+			t.Errorf("skuOf(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestPlacementFingerprint(t *testing.T) {
+	a := PlacedCarton{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}, Orientation: "original"}
+	b := PlacedCarton{CartonID: "A_2", Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}, Orientation: "original"}
+	jittered := b
+	jittered.Position.X += 0.0001
+
+	first := Pallet{Cartons: []PlacedCarton{a, b}}.PlacementFingerprint()
+	second := Pallet{Cartons: []PlacedCarton{jittered, a}}.PlacementFingerprint()
+	if first != second {
+		t.Errorf("expected identical fingerprints, got %s and %s", first, second)
+	}
+
+	moved := b
+	moved.Position.Y = 200
+	if third := (Pallet{Cartons: []PlacedCarton{a, moved}}).PlacementFingerprint(); third == first {
+		t.Error("expected different fingerprint for a different layout")
+	}
+}
+
+func TestSolidAreaAtHeight(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 100, Height: 100}},
+			{Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 100, Height: 200}},
+			{Position: Point3D{X: 0, Y: 0, Z: 100}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}},
+		},
+	}
+
+	tests := []struct {
+		z        float64
+		expected float64
+	}{
+		{0, 40000},
+		{50, 40000},
+		{100, 30000},
+		{200, 0},
+	}
+	for _, tt := range tests {
+		if got := pallet.SolidAreaAtHeight(tt.z); got != tt.expected {
+			t.Errorf("z=%f: expected area %f, got %f", tt.z, tt.expected, got)
+		}
+	}
+}
+
+func TestHeavyOnBottomScore(t *testing.T) {
+	box := Dimensions{Length: 100, Width: 100, Height: 100}
+	heavyLow := Pallet{
+		Cartons: []PlacedCarton{
+			{Position: Point3D{Z: 0}, Dimensions: box, Weight: 3000},
+			{Position: Point3D{Z: 100}, Dimensions: box, Weight: 2000},
+			{Position: Point3D{Z: 200}, Dimensions: box, Weight: 1000},
+		},
+	}
+	if score := heavyLow.HeavyOnBottomScore(); score < 0.999 {
+		t.Errorf("expected score ~1, got %f", score)
+	}
+
+	heavyHigh := Pallet{
+		Cartons: []PlacedCarton{
+			{Position: Point3D{Z: 0}, Dimensions: box, Weight: 1000},
+			{Position: Point3D{Z: 100}, Dimensions: box, Weight: 3000},
+		},
+	}
+	if score := heavyHigh.HeavyOnBottomScore(); score > -0.999 {
+		t.Errorf("expected score ~-1, got %f", score)
+	}
+
+	if score := (Pallet{}).HeavyOnBottomScore(); score != 0 {
+		t.Errorf("expected score 0 for empty pallet, got %f", score)
+	}
+}
+
+func TestLayerPatternType(t *testing.T) {
+	box := Dimensions{Length: 200, Width: 100, Height: 100}
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: box, Layer: 0},
+			{Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: box, Layer: 0},
+			// Layer 1 stacks directly on layer 0.
+			{Position: Point3D{X: 0, Y: 0, Z: 100}, Dimensions: box, Layer: 1},
+			{Position: Point3D{X: 200, Y: 0, Z: 100}, Dimensions: box, Layer: 1},
+			// Layer 2 is offset by half a carton.
+			{Position: Point3D{X: 100, Y: 0, Z: 200}, Dimensions: box, Layer: 2},
+			// Layer 3 has one aligned and one offset carton.
+			{Position: Point3D{X: 100, Y: 0, Z: 300}, Dimensions: box, Layer: 3},
+			{Position: Point3D{X: 300, Y: 0, Z: 300}, Dimensions: box, Layer: 3},
+		},
+	}
+
+	tests := map[int]string{0: "", 1: "column", 2: "brick", 3: "mixed", 4: ""}
+	for layer, expected := range tests {
+		if got := pallet.LayerPatternType(layer); got != expected {
+			t.Errorf("layer %d: expected %q, got %q", layer, expected, got)
+		}
+	}
+}
+
+func TestMaxStackingPressure(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "BIG_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 400, Width: 400, Height: 100}, Weight: 1000},
+			{CartonID: "SMALL_1", Position: Point3D{X: 0, Y: 0, Z: 100}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}, Weight: 500},
+			{CartonID: "TOP_1", Position: Point3D{X: 0, Y: 0, Z: 200}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}, Weight: 2000},
+		},
+	}
+
+	pressure, id := pallet.MaxStackingPressure()
+	if id != "SMALL_1" || pressure != 0.2 {
+		t.Errorf("expected SMALL_1 at 0.2 g/mm², got %s at %f", id, pressure)
+	}
+
+	if pressure, id := (Pallet{}).MaxStackingPressure(); pressure != 0 || id != "" {
+		t.Errorf("expected no pressure for empty pallet, got %s at %f", id, pressure)
+	}
+}
+
+func TestGrippableCartons(t *testing.T) {
+	box := Dimensions{Length: 100, Width: 100, Height: 100}
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			// A row of three touching cartons with a 50mm gap before a fourth.
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: box},
+			{CartonID: "A_2", Position: Point3D{X: 100, Y: 0, Z: 0}, Dimensions: box},
+			{CartonID: "A_3", Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: box},
+			{CartonID: "A_4", Position: Point3D{X: 350, Y: 0, Z: 0}, Dimensions: box},
+			// A fifth carton sitting on A_1, which buries it.
+			{CartonID: "B_1", Position: Point3D{X: 0, Y: 0, Z: 100}, Dimensions: box},
+			// A sixth carton directly behind A_4.
+			{CartonID: "C_1", Position: Point3D{X: 350, Y: 100, Z: 0}, Dimensions: box},
+		},
+	}
+
+	// A_1 is buried. A_2 and A_3 touch on their X faces but are open on
+	// both Y faces. A_4 has C_1 directly behind it but 50mm on its X faces.
+	// C_1 has nothing beside it on X.
+	grippable := pallet.GrippableCartons(40)
+	expected := []string{"A_2", "A_3", "A_4", "B_1", "C_1"}
+	if len(grippable) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, grippable)
+	}
+	for i := range expected {
+		if grippable[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, grippable)
+			break
+		}
+	}
+
+	// A 60mm requirement rules out A_4.
+	grippable = pallet.GrippableCartons(60)
+	expected = []string{"A_2", "A_3", "B_1", "C_1"}
+	if len(grippable) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, grippable)
+	}
+}
+
+func TestFootprintAndDensity(t *testing.T) {
+	pallet := Pallet{
+		TotalWeight: 8000,
+		TotalHeight: 200,
+		Cartons: []PlacedCarton{
+			{Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 100, Width: 200, Height: 200}},
+			{Position: Point3D{X: 100, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}},
+		},
+	}
+
+	length, width := pallet.Footprint()
+	if length != 200 || width != 200 {
+		t.Errorf("expected footprint 200x200, got %fx%f", length, width)
+	}
+	if density := pallet.Density(); density != 0.001 {
+		t.Errorf("expected density 0.001 g/mm³, got %f", density)
+	}
+	if density := (Pallet{}).Density(); density != 0 {
+		t.Errorf("expected density 0 for empty pallet, got %f", density)
+	}
+}
+
+func TestExposedSurfaceArea(t *testing.T) {
+	box := Dimensions{Length: 100, Width: 100, Height: 100}
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: box},
+			{CartonID: "A_2", Position: Point3D{X: 100, Y: 0, Z: 0}, Dimensions: box},
+			{CartonID: "A_3", Position: Point3D{X: 50, Y: 0, Z: 100}, Dimensions: box},
+		},
+	}
+
+	tests := map[string]float64{
+		// Six faces of 10000, minus the deck, the side touching A_2, and
+		// half the top under A_3.
+		"A_1": 60000 - 10000 - 10000 - 5000,
+		// Six faces, minus both halves of its base resting on A_1 and A_2.
+		"A_3": 60000 - 10000,
+		"B_1": 0,
+	}
+	for id, expected := range tests {
+		if got := pallet.ExposedSurfaceArea(id); got != expected {
+			t.Errorf("%s: expected %f, got %f", id, expected, got)
+		}
+	}
+}
+
+func TestFullLayerRatio(t *testing.T) {
+	box := Dimensions{Length: 100, Width: 100, Height: 100}
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			// Layer 0: a complete 2x2 block.
+			{Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: box},
+			{Position: Point3D{X: 100, Y: 0, Z: 0}, Dimensions: box},
+			{Position: Point3D{X: 0, Y: 100, Z: 0}, Dimensions: box},
+			{Position: Point3D{X: 100, Y: 100, Z: 0}, Dimensions: box},
+			// Layer 1: an L shape missing one corner.
+			{Position: Point3D{X: 0, Y: 0, Z: 100}, Dimensions: box, Layer: 1},
+			{Position: Point3D{X: 100, Y: 0, Z: 100}, Dimensions: box, Layer: 1},
+			{Position: Point3D{X: 0, Y: 100, Z: 100}, Dimensions: box, Layer: 1},
+		},
+	}
+
+	if ratio := pallet.FullLayerRatio(); ratio != 0.5 {
+		t.Errorf("expected ratio 0.5, got %f", ratio)
+	}
+	if ratio := (Pallet{}).FullLayerRatio(); ratio != 0 {
+		t.Errorf("expected ratio 0 for empty pallet, got %f", ratio)
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	carton := PlacedCarton{
+		CartonID:   "A_1",
+		Position:   Point3D{X: 12.4, Y: 17.6, Z: 0.2},
+		Dimensions: Dimensions{Length: 609.6, Width: 457.2, Height: 406.4},
+	}
+
+	q := carton.Quantize(5)
+	if q.Position != (Point3D{X: 10, Y: 20, Z: 0}) {
+		t.Errorf("unexpected quantized position: %v", q.Position)
+	}
+	if q.Dimensions != (Dimensions{Length: 610, Width: 455, Height: 405}) {
+		t.Errorf("unexpected quantized dimensions: %v", q.Dimensions)
+	}
+	if carton.Position.X != 12.4 {
+		t.Error("expected original carton unchanged")
+	}
+	if carton.Quantize(0) != carton {
+		t.Error("expected zero grid to leave carton unchanged")
+	}
+}
+
+func TestAccessibilityRank(t *testing.T) {
+	// A 3x3 base layer with one carton on the corner and one in the middle
+	// of the second layer.
+	var cartons []PlacedCarton
+	for i := 0; i < 9; i++ {
+		cartons = append(cartons, PlacedCarton{
+			CartonID:   fmt.Sprintf("BASE_%d", i+1),
+			Position:   Point3D{X: float64(i%3) * 100, Y: float64(i/3) * 100},
+			Dimensions: Dimensions{Length: 100, Width: 100, Height: 100},
+		})
+	}
+	cartons = append(cartons,
+		PlacedCarton{CartonID: "TOP_1", Position: Point3D{X: 0, Y: 0, Z: 100}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}, Layer: 1},
+		PlacedCarton{CartonID: "TOP_2", Position: Point3D{X: 100, Y: 100, Z: 100}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}, Layer: 1},
+	)
+	ranks := Pallet{Cartons: cartons}.AccessibilityRank()
+
+	if len(ranks) != 11 {
+		t.Fatalf("expected 11 ranks, got %d", len(ranks))
+	}
+	if ranks["TOP_1"] != 1 {
+		t.Errorf("expected the top corner carton to rank 1, got %d", ranks["TOP_1"])
+	}
+	if ranks["BASE_1"] != 2 {
+		t.Errorf("expected edge cartons to follow, ranked by ID, got BASE_1=%d", ranks["BASE_1"])
+	}
+	if ranks["TOP_2"] != 10 || ranks["BASE_5"] != 11 {
+		t.Errorf("expected TOP_2 and the buried center carton last, got %d and %d", ranks["TOP_2"], ranks["BASE_5"])
+	}
+}
+
+func TestZoneCohesion(t *testing.T) {
+	row := func(zones ...string) Pallet {
+		var p Pallet
+		for i, zone := range zones {
+			p.Cartons = append(p.Cartons, PlacedCarton{
+				CartonID:   fmt.Sprintf("C_%d", i+1),
+				Position:   Point3D{X: float64(i) * 100},
+				Dimensions: Dimensions{Length: 100, Width: 100, Height: 100},
+				Zone:       zone,
+			})
+		}
+		return p
+	}
+
+	// The inner cartons are equally near a same-zone and an other-zone
+	// neighbor, so each counts half.
+	if got := row("A", "A", "B", "B").ZoneCohesion(); got != 0.75 {
+		t.Errorf("expected clustered zones to score 0.75, got %f", got)
+	}
+	if got := row("A", "A", "A", "B", "B", "B").ZoneCohesion(); got <= 0.75 {
+		t.Errorf("expected larger clusters to score higher, got %f", got)
+	}
+	if got := row("A", "B", "A", "B").ZoneCohesion(); got != 0 {
+		t.Errorf("expected alternating zones to score 0, got %f", got)
+	}
+	if got := row("A", "", "").ZoneCohesion(); got != 1 {
+		t.Errorf("expected a single zoned carton to score 1, got %f", got)
+	}
+}
+
+func TestOutOfBoundsCartons(t *testing.T) {
+	constraints := PackingConstraints{MaxLength: 1000, MaxWidth: 800, MaxHeight: 1500}
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "INSIDE_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 1000, Width: 800, Height: 500}},
+			{CartonID: "ROUNDING_1", Position: Point3D{X: 600.3, Y: 0, Z: 500}, Dimensions: Dimensions{Length: 400, Width: 400, Height: 500}},
+			{CartonID: "NEGATIVE_1", Position: Point3D{X: -10, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}},
+			{CartonID: "TOO_WIDE_1", Position: Point3D{X: 0, Y: 500, Z: 500}, Dimensions: Dimensions{Length: 400, Width: 400, Height: 500}},
+			{CartonID: "TOO_TALL_1", Position: Point3D{X: 0, Y: 0, Z: 1200}, Dimensions: Dimensions{Length: 400, Width: 400, Height: 500}},
+		},
+	}
+
+	got := pallet.OutOfBoundsCartons(constraints)
+	expected := []string{"NEGATIVE_1", "TOO_WIDE_1", "TOO_TALL_1"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestBalanceOffsets(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0}, Dimensions: Dimensions{Length: 500, Width: 400, Height: 100}, Weight: 3000},
+			{CartonID: "A_2", Position: Point3D{X: 500, Y: 0}, Dimensions: Dimensions{Length: 500, Width: 400, Height: 100}, Weight: 1000},
+			{CartonID: "A_3", Position: Point3D{X: 0, Y: 400}, Dimensions: Dimensions{Length: 1000, Width: 400, Height: 100}, Weight: 4000},
+		},
+	}
+
+	// COG is at X = (250*3000 + 750*1000 + 500*4000) / 8000 = 437.5 and
+	// Y = (200*4000 + 600*4000) / 8000 = 400, the center of the width.
+	lateral, longitudinal := pallet.BalanceOffsets()
+	if math.Abs(lateral) > 1e-9 {
+		t.Errorf("expected no lateral offset, got %f", lateral)
+	}
+	if math.Abs(longitudinal-(-0.125)) > 1e-9 {
+		t.Errorf("expected longitudinal offset -0.125, got %f", longitudinal)
+	}
+
+	if lateral, longitudinal := (Pallet{}).BalanceOffsets(); lateral != 0 || longitudinal != 0 {
+		t.Errorf("expected zero offsets for an empty pallet, got %f, %f", lateral, longitudinal)
+	}
+}
+
+func TestWeightByLayer(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Layer: 0, Weight: 5000},
+			{CartonID: "A_2", Layer: 0, Weight: 4000},
+			{CartonID: "B_1", Layer: 1, Weight: 2500},
+			{CartonID: "C_1", Layer: 2, Weight: 1000},
+			{CartonID: "C_2", Layer: 2, Weight: 500},
+		},
+	}
+
+	expected := map[int]float64{0: 9000, 1: 2500, 2: 1500}
+	if got := pallet.WeightByLayer(); !maps.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if n := pallet.LayerCount(); n != 3 {
+		t.Errorf("expected 3 layers, got %d", n)
+	}
+
+	empty := (Pallet{}).WeightByLayer()
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("expected empty non-nil map for an empty pallet, got %v", empty)
+	}
+}
+
+func TestIsStable(t *testing.T) {
+	base := Pallet{
+		TotalHeight: 1000,
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Dimensions: Dimensions{Length: 1200, Width: 800, Height: 1000}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cog      Point3D
+		expected bool
+	}{
+		{"centered", Point3D{X: 600, Y: 400, Z: 400}, true},
+		{"too high", Point3D{X: 600, Y: 400, Z: 600}, false},
+		{"skewed along Y", Point3D{X: 600, Y: 700, Z: 400}, false},
+		{"skewed along X", Point3D{X: 100, Y: 400, Z: 400}, false},
+		{"slightly off-center", Point3D{X: 700, Y: 350, Z: 400}, true},
+	}
+	for _, tt := range tests {
+		pallet := base
+		pallet.CenterOfGravity = tt.cog
+		if got := pallet.IsStable(0.5, 0.25); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	if !(Pallet{}).IsStable(0, 0) {
+		t.Error("expected an empty pallet to be stable")
+	}
+}
+
+func TestUsedOrientations(t *testing.T) {
+	pallet := Pallet{
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Orientation: "rotated_z"},
+			{CartonID: "A_2", Orientation: "original"},
+			{CartonID: "A_3", Orientation: "rotated_z"},
+			{CartonID: "B_1", Orientation: "original"},
+		},
+	}
+
+	got := pallet.UsedOrientations()
+	if fmt.Sprint(got) != "[original rotated_z]" {
+		t.Errorf("expected [original rotated_z], got %v", got)
+	}
+	if got := (Pallet{}).UsedOrientations(); len(got) != 0 {
+		t.Errorf("expected no orientations for an empty pallet, got %v", got)
+	}
+}
@@ -0,0 +1,39 @@
+package palletizer
+
+import "sync"
+
+// palletRegistry holds the named pallet presets for RegisterPallet and
+// LookupPallet, starting with the built-in presets
+var palletRegistry = struct {
+	mu      sync.RWMutex
+	pallets map[string]PackingConstraints
+}{
+	pallets: map[string]PackingConstraints{
+		"standard":      StandardPallet(),
+		"standard-4048": StandardPallet4048(),
+		"eur":           EURPallet(),
+		"eur2":          EURPallet2(),
+	},
+}
+
+// RegisterPallet registers constraints under name so that any part of an
+// application can look them up with LookupPallet. Registering a name that
+// is already taken replaces its constraints, including those of the built-in
+// presets "standard", "standard-4048", "eur" and "eur2". It is safe to call
+// concurrently with LookupPallet.
+func RegisterPallet(name string, c PackingConstraints) {
+	palletRegistry.mu.Lock()
+	defer palletRegistry.mu.Unlock()
+	palletRegistry.pallets[name] = c
+}
+
+// LookupPallet returns the constraints registered under name, reporting
+// whether there are any. The built-in presets are registered as "standard"
+// (StandardPallet), "standard-4048" (StandardPallet4048), "eur" (EURPallet)
+// and "eur2" (EURPallet2).
+func LookupPallet(name string) (PackingConstraints, bool) {
+	palletRegistry.mu.RLock()
+	defer palletRegistry.mu.RUnlock()
+	c, ok := palletRegistry.pallets[name]
+	return c, ok
+}
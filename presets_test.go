@@ -0,0 +1,54 @@
+package palletizer
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestLookupPalletBuiltins(t *testing.T) {
+	for name, expected := range map[string]PackingConstraints{
+		"standard":      StandardPallet(),
+		"standard-4048": StandardPallet4048(),
+		"eur":           EURPallet(),
+		"eur2":          EURPallet2(),
+	} {
+		if got, ok := LookupPallet(name); !ok || got != expected {
+			t.Errorf("%s: expected %+v, got %+v (found %v)", name, expected, got, ok)
+		}
+	}
+	if _, ok := LookupPallet("missing"); ok {
+		t.Error("expected an unknown name not to be found")
+	}
+}
+
+func TestRegisterPallet(t *testing.T) {
+	site := PackingConstraints{MaxLength: 1100, MaxWidth: 1100, MaxHeight: 1500, MaxWeight: 800000}
+	RegisterPallet("test-site", site)
+	if got, ok := LookupPallet("test-site"); !ok || got != site {
+		t.Errorf("expected %+v, got %+v (found %v)", site, got, ok)
+	}
+
+	// Built-ins can be overridden
+	RegisterPallet("eur", site)
+	defer RegisterPallet("eur", EURPallet())
+	if got, _ := LookupPallet("eur"); got != site {
+		t.Errorf("expected the override, got %+v", got)
+	}
+}
+
+func TestRegisterPalletConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterPallet("concurrent-"+strconv.Itoa(i), StandardPallet())
+		}()
+		go func() {
+			defer wg.Done()
+			LookupPallet("standard")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,110 @@
+package palletizer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ReconcilePlacements compares planned placements against those detected by
+// a physical scan and describes each discrepancy. Cartons are paired by ID
+// first; remaining planned cartons are paired with the nearest remaining
+// scanned carton within tolMM. A carton paired by ID but displaced by more
+// than tolMM is reported as misplaced; unpaired planned cartons as missing
+// and unpaired scanned cartons as extra. It returns nil when plan and scan
+// agree.
+func ReconcilePlacements(plan []PlacedCarton, scanned []PlacedCarton, tolMM float64) []string {
+	var messages []string
+	used := make([]bool, len(scanned))
+	var unmatched []PlacedCarton
+
+	for _, p := range plan {
+		idx := -1
+		for i, s := range scanned {
+			if !used[i] && s.CartonID == p.CartonID {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			unmatched = append(unmatched, p)
+			continue
+		}
+		used[idx] = true
+		if d := distance(p.Position, scanned[idx].Position); d > tolMM {
+			messages = append(messages, fmt.Sprintf("misplaced: %s at %s, planned at %s (off by %.1f mm)",
+				p.CartonID, formatPoint(scanned[idx].Position), formatPoint(p.Position), d))
+		}
+	}
+
+	for _, p := range unmatched {
+		idx, best := -1, math.Inf(1)
+		for i, s := range scanned {
+			if d := distance(p.Position, s.Position); !used[i] && d <= tolMM && d < best {
+				idx, best = i, d
+			}
+		}
+		if idx < 0 {
+			messages = append(messages, fmt.Sprintf("missing: %s planned at %s", p.CartonID, formatPoint(p.Position)))
+			continue
+		}
+		used[idx] = true
+	}
+
+	for i, s := range scanned {
+		if !used[i] {
+			messages = append(messages, fmt.Sprintf("extra: %s at %s", s.CartonID, formatPoint(s.Position)))
+		}
+	}
+	return messages
+}
+
+// distance returns the Euclidean distance between a and b
+func distance(a, b Point3D) float64 {
+	return math.Sqrt((a.X-b.X)*(a.X-b.X) + (a.Y-b.Y)*(a.Y-b.Y) + (a.Z-b.Z)*(a.Z-b.Z))
+}
+
+// ValidateDimensionInvariance checks that the server rotated, but never
+// resized, each placed carton: its dimensions, sorted, must equal those of
+// the request carton it originates from to within half a millimeter. It returns
+// a message naming each placed carton that differs, or nil when all match.
+// Placed cartons that match no request carton are not checked.
+func ValidateDimensionInvariance(req *PackingRequest, resp *PackingResponse) []string {
+	var messages []string
+	for _, p := range resp.Pallets {
+		for _, c := range p.Cartons {
+			original, ok := req.cartonFor(c.CartonID)
+			if !ok {
+				continue
+			}
+			placed := sortedDimensions(c.Dimensions.Length, c.Dimensions.Width, c.Dimensions.Height)
+			want := sortedDimensions(original.Length, original.Width, original.Height)
+			for i := range placed {
+				if math.Abs(placed[i]-want[i]) > positionTolerance {
+					messages = append(messages, fmt.Sprintf("resized: %s on pallet %d is %s, carton %s is %s",
+						c.CartonID, p.PalletID, formatDimensions(c.Dimensions), original.ID,
+						formatDimensions(Dimensions{Length: original.Length, Width: original.Width, Height: original.Height})))
+					break
+				}
+			}
+		}
+	}
+	return messages
+}
+
+// sortedDimensions returns the three dimensions in ascending order
+func sortedDimensions(l, w, h float64) []float64 {
+	dims := []float64{l, w, h}
+	slices.Sort(dims)
+	return dims
+}
+
+// formatDimensions formats dimensions as "LxWxH" in whole millimeters
+func formatDimensions(d Dimensions) string {
+	return fmt.Sprintf("%.0fx%.0fx%.0f", d.Length, d.Width, d.Height)
+}
+
+// formatPoint formats p as "(x, y, z)" in whole millimeters
+func formatPoint(p Point3D) string {
+	return fmt.Sprintf("(%.0f, %.0f, %.0f)", p.X, p.Y, p.Z)
+}
@@ -0,0 +1,66 @@
+package palletizer
+
+import "testing"
+
+func TestReconcilePlacements(t *testing.T) {
+	plan := []PlacedCarton{
+		{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}},
+		{CartonID: "A_2", Position: Point3D{X: 400, Y: 0, Z: 0}},
+		{CartonID: "A_3", Position: Point3D{X: 800, Y: 0, Z: 0}},
+		{CartonID: "A_4", Position: Point3D{X: 0, Y: 400, Z: 0}},
+	}
+	scanned := []PlacedCarton{
+		{CartonID: "A_1", Position: Point3D{X: 3, Y: 4, Z: 0}},     // within tolerance
+		{CartonID: "A_2", Position: Point3D{X: 460, Y: 0, Z: 0}},   // misplaced
+		{CartonID: "", Position: Point3D{X: 805, Y: 0, Z: 0}},      // unlabeled, matched by position
+		{CartonID: "B_1", Position: Point3D{X: 400, Y: 400, Z: 0}}, // extra
+	}
+
+	messages := ReconcilePlacements(plan, scanned, 10)
+	expected := []string{
+		"misplaced: A_2 at (460, 0, 0), planned at (400, 0, 0) (off by 60.0 mm)",
+		"missing: A_4 planned at (0, 400, 0)",
+		"extra: B_1 at (400, 400, 0)",
+	}
+	if len(messages) != len(expected) {
+		t.Fatalf("expected %d messages, got %d: %v", len(expected), len(messages), messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("message %d: expected %q, got %q", i, expected[i], messages[i])
+		}
+	}
+
+	if messages := ReconcilePlacements(plan, plan, 1); messages != nil {
+		t.Errorf("expected no discrepancies, got %v", messages)
+	}
+}
+
+func TestValidateDimensionInvariance(t *testing.T) {
+	req := &PackingRequest{Cartons: []Carton{
+		{ID: "BOX001", Length: 400, Width: 300, Height: 200},
+		{ID: "BOX002", Length: 600, Width: 400, Height: 300},
+	}}
+	resp := &PackingResponse{Pallets: []Pallet{
+		{PalletID: 1, Cartons: []PlacedCarton{
+			{CartonID: "BOX001_1", Dimensions: Dimensions{Length: 400, Width: 300, Height: 200}},
+			{CartonID: "BOX001_2", Dimensions: Dimensions{Length: 200, Width: 400, Height: 299.8}},
+			{CartonID: "BOX002_1", Dimensions: Dimensions{Length: 400, Width: 600, Height: 300.2}},
+		}},
+		{PalletID: 2, Cartons: []PlacedCarton{
+			{CartonID: "BOX002_2", Dimensions: Dimensions{Length: 600, Width: 400, Height: 250}},
+			{CartonID: "UNKNOWN_1", Dimensions: Dimensions{Length: 1, Width: 1, Height: 1}},
+		}},
+	}}
+
+	messages := ValidateDimensionInvariance(req, resp)
+	expected := []string{"resized: BOX002_2 on pallet 2 is 600x400x250, carton BOX002 is 600x400x300"}
+	if len(messages) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], messages[i])
+		}
+	}
+}
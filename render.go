@@ -0,0 +1,66 @@
+package palletizer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+)
+
+// orientationColors fill cartons in RenderLayerSVG, one per orientation used
+// on the pallet, repeating when there are more orientations than colors
+var orientationColors = []string{"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3", "#fdb462"}
+
+// WriteStackGraphDOT writes a Graphviz DOT digraph of which carton rests on
+// which. Nodes are labeled with placed carton IDs, and each edge points from
+// a supporting carton to a carton it supports, that is one whose bottom face
+// meets its top face over an overlapping footprint.
+func (p Pallet) WriteStackGraphDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", fmt.Sprintf("pallet %d", p.PalletID))
+	for _, c := range p.Cartons {
+		fmt.Fprintf(&b, "\t%q;\n", c.CartonID)
+	}
+	for i, below := range p.Cartons {
+		for j, top := range p.Cartons {
+			if i != j && supportArea(top, below) > 0 {
+				fmt.Fprintf(&b, "\t%q -> %q;\n", below.CartonID, top.CartonID)
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderLayerSVG writes a top-down SVG diagram of one layer of the pallet:
+// the footprint as an outline and each carton of the layer as a rectangle at
+// its X/Y position, labeled with its carton ID. The drawing is to scale, one
+// SVG unit per millimeter with the origin at the top left, and cartons are
+// colored by orientation so that rotated cartons stand out.
+func (p Pallet) RenderLayerSVG(layer int, w io.Writer) error {
+	length, width := p.Footprint()
+	orientations := p.UsedOrientations()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%gmm" height="%gmm" viewBox="0 0 %g %g">`+"\n", length, width, length, width)
+	fmt.Fprintf(&b, "\t<title>Pallet %d, layer %d</title>\n", p.PalletID, layer)
+	fmt.Fprintf(&b, "\t<rect x=\"0\" y=\"0\" width=\"%g\" height=\"%g\" fill=\"none\" stroke=\"black\" stroke-width=\"4\"/>\n", length, width)
+	for _, c := range p.Cartons {
+		if c.Layer != layer {
+			continue
+		}
+		color := orientationColors[slices.Index(orientations, c.Orientation)%len(orientationColors)]
+		fmt.Fprintf(&b, "\t<rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\" fill=\"%s\" stroke=\"black\" stroke-width=\"2\"/>\n",
+			c.Position.X, c.Position.Y, c.Dimensions.Length, c.Dimensions.Width, color)
+		fmt.Fprintf(&b, "\t<text x=\"%g\" y=\"%g\" font-size=\"%g\" text-anchor=\"middle\" dominant-baseline=\"middle\">%s</text>\n",
+			c.Position.X+c.Dimensions.Length/2, c.Position.Y+c.Dimensions.Width/2,
+			min(c.Dimensions.Length, c.Dimensions.Width)/5, html.EscapeString(c.CartonID))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
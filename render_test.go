@@ -0,0 +1,73 @@
+package palletizer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteStackGraphDOT(t *testing.T) {
+	pallet := Pallet{
+		PalletID: 1,
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}},
+			{CartonID: "A_2", Position: Point3D{X: 200, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}},
+			{CartonID: "B_1", Position: Point3D{X: 100, Y: 0, Z: 100}, Dimensions: Dimensions{Length: 200, Width: 200, Height: 100}},
+		},
+	}
+
+	var b strings.Builder
+	if err := pallet.WriteStackGraphDOT(&b); err != nil {
+		t.Fatalf("WriteStackGraphDOT failed: %v", err)
+	}
+	expected := `digraph "pallet 1" {
+	"A_1";
+	"A_2";
+	"B_1";
+	"A_1" -> "B_1";
+	"A_2" -> "B_1";
+}
+`
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestRenderLayerSVG(t *testing.T) {
+	pallet := Pallet{
+		PalletID: 1,
+		Cartons: []PlacedCarton{
+			{CartonID: "A_1", Position: Point3D{X: 0, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 600, Width: 400, Height: 200}, Orientation: "original", Layer: 0},
+			{CartonID: "A_2", Position: Point3D{X: 600, Y: 0, Z: 0}, Dimensions: Dimensions{Length: 600, Width: 400, Height: 200}, Orientation: "original", Layer: 0},
+			{CartonID: "B<1>", Position: Point3D{X: 0, Y: 400, Z: 0}, Dimensions: Dimensions{Length: 400, Width: 400, Height: 200}, Orientation: "rotated_lw", Layer: 0},
+			{CartonID: "C_1", Position: Point3D{X: 0, Y: 0, Z: 200}, Dimensions: Dimensions{Length: 1200, Width: 800, Height: 200}, Orientation: "original", Layer: 1},
+		},
+	}
+
+	var b strings.Builder
+	if err := pallet.RenderLayerSVG(0, &b); err != nil {
+		t.Fatalf("RenderLayerSVG failed: %v", err)
+	}
+	svg := b.String()
+
+	// One outline for the footprint plus one rectangle per carton in layer 0
+	if n := strings.Count(svg, "<rect"); n != 4 {
+		t.Errorf("expected 4 rect elements, got %d", n)
+	}
+	if !strings.Contains(svg, `viewBox="0 0 1200 800"`) {
+		t.Errorf("expected the footprint as the view box, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, ">B&lt;1&gt;</text>") || strings.Contains(svg, "C_1") {
+		t.Errorf("expected labels for layer 0 cartons only, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, orientationColors[0]) || !strings.Contains(svg, orientationColors[1]) {
+		t.Errorf("expected a color per orientation, got:\n%s", svg)
+	}
+
+	b.Reset()
+	if err := pallet.RenderLayerSVG(5, &b); err != nil {
+		t.Fatalf("RenderLayerSVG failed: %v", err)
+	}
+	if n := strings.Count(b.String(), "<rect"); n != 1 {
+		t.Errorf("expected only the outline for an empty layer, got %d rect elements", n)
+	}
+}
@@ -0,0 +1,284 @@
+package palletizer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Validate checks that the packing request is well formed before it is
+// sent: every carton must be valid, the packing constraints positive and the
+// packing options in range, and every carton must fit within the
+// constraints' dimensions in one of the orientations it allows (so an
+// UprightOnly carton must fit with its height vertical). The returned error
+// joins every problem found, each naming the offending carton and field.
+func (r *PackingRequest) Validate() error {
+	var errs []error
+	constraintsErr := r.PackingConstraints.Validate()
+	for _, c := range r.Cartons {
+		err := c.Validate()
+		errs = append(errs, err)
+		if err == nil && constraintsErr == nil && !c.fitsWithin(r.PackingConstraints) {
+			errs = append(errs, fmt.Errorf("carton %s: does not fit within the pallet dimensions in any allowed orientation", c.ID))
+		}
+	}
+	errs = append(errs, constraintsErr, r.PackingOptions.Validate())
+	return errors.Join(errs...)
+}
+
+// Validate checks the carton's fields: it must have an ID, positive
+// dimensions and weight, a quantity of at least one, and a nested height
+// within its height if it is nestable. The returned error joins every
+// problem found.
+func (c Carton) Validate() error {
+	name := c.ID
+	if name == "" {
+		name = "(no ID)"
+	}
+
+	var errs []error
+	if c.ID == "" {
+		errs = append(errs, errors.New("carton (no ID): id must not be empty"))
+	}
+	for _, f := range []struct {
+		field string
+		value float64
+	}{{"length", c.Length}, {"width", c.Width}, {"height", c.Height}, {"weight", c.Weight}} {
+		if f.value <= 0 {
+			errs = append(errs, fmt.Errorf("carton %s: %s must be positive, got %g", name, f.field, f.value))
+		}
+	}
+	if c.Quantity < 1 {
+		errs = append(errs, fmt.Errorf("carton %s: quantity must be at least 1, got %d", name, c.Quantity))
+	}
+	if c.Nestable && (c.NestedHeight < 0 || c.NestedHeight > c.Height) {
+		errs = append(errs, fmt.Errorf("carton %s: nested_height must be between 0 and height (%g), got %g", name, c.Height, c.NestedHeight))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that every pallet limit is positive. The returned error
+// joins every problem found.
+func (p PackingConstraints) Validate() error {
+	var errs []error
+	for _, f := range []struct {
+		field string
+		value float64
+	}{{"max_length", p.MaxLength}, {"max_width", p.MaxWidth}, {"max_height", p.MaxHeight}, {"max_weight", p.MaxWeight}} {
+		if f.value <= 0 {
+			errs = append(errs, fmt.Errorf("packing_constraints: %s must be positive, got %g", f.field, f.value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Volume returns the carton's volume in cubic millimeters
+func (c Carton) Volume() float64 {
+	return c.Length * c.Width * c.Height
+}
+
+// TotalVolume returns the combined volume in cubic millimeters of every
+// carton in the request, counting each carton Quantity times
+func (r *PackingRequest) TotalVolume() float64 {
+	var volume float64
+	for _, c := range r.Cartons {
+		volume += c.Volume() * float64(c.Quantity)
+	}
+	return volume
+}
+
+// DensityOutliers returns the IDs of cartons whose density (weight divided
+// by volume) lies more than zThreshold population standard deviations from
+// the mean density of the request's cartons, which often points to a typo in
+// a weight or dimension. Each carton line counts once regardless of
+// Quantity, and cartons without a positive volume are skipped. It returns
+// nil when the densities do not vary.
+func (r *PackingRequest) DensityOutliers(zThreshold float64) []string {
+	var cartons []Carton
+	var densities []float64
+	var mean float64
+	for _, c := range r.Cartons {
+		if v := c.Volume(); v > 0 {
+			cartons = append(cartons, c)
+			densities = append(densities, c.Weight/v)
+			mean += c.Weight / v
+		}
+	}
+	if len(densities) == 0 {
+		return nil
+	}
+	mean /= float64(len(densities))
+
+	var variance float64
+	for _, d := range densities {
+		variance += (d - mean) * (d - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(densities)))
+	if stddev == 0 {
+		return nil
+	}
+
+	var ids []string
+	for i, d := range densities {
+		if math.Abs(d-mean)/stddev > zThreshold {
+			ids = append(ids, cartons[i].ID)
+		}
+	}
+	return ids
+}
+
+// Orientations returns the distinct dimensions the carton may be placed in.
+// A carton that does not allow rotation has only its original orientation;
+// an UprightOnly carton may only swap its length and width; otherwise all
+// six axis permutations are allowed.
+func (c Carton) Orientations() []Dimensions {
+	l, w, h := c.Length, c.Width, c.Height
+	candidates := []Dimensions{{Length: l, Width: w, Height: h}}
+	switch {
+	case !c.AllowRotation:
+	case c.UprightOnly:
+		candidates = append(candidates, Dimensions{Length: w, Width: l, Height: h})
+	default:
+		candidates = append(candidates,
+			Dimensions{Length: w, Width: l, Height: h},
+			Dimensions{Length: l, Width: h, Height: w},
+			Dimensions{Length: h, Width: l, Height: w},
+			Dimensions{Length: w, Width: h, Height: l},
+			Dimensions{Length: h, Width: w, Height: l},
+		)
+	}
+
+	var orientations []Dimensions
+	for _, d := range candidates {
+		if !slices.Contains(orientations, d) {
+			orientations = append(orientations, d)
+		}
+	}
+	return orientations
+}
+
+// CartonsUnderHeight returns how many copies of c fit in a single vertical
+// stack no taller than maxHeightMM, standing each copy in the orientation
+// with the smallest height that Orientations allows. A Nestable carton with
+// a NestedHeight may instead nest in its original orientation, each copy
+// after the first adding only NestedHeight; the taller count is returned.
+// It returns 0 if the carton's dimensions are not positive.
+func CartonsUnderHeight(c Carton, maxHeightMM float64) int {
+	height := math.Inf(1)
+	for _, d := range c.Orientations() {
+		height = min(height, d.Height)
+	}
+	if height <= 0 || c.Length <= 0 || c.Width <= 0 || maxHeightMM < height {
+		return 0
+	}
+	count := int(maxHeightMM / height)
+	if c.Nestable && c.NestedHeight > 0 && c.Height <= maxHeightMM {
+		count = max(count, 1+int((maxHeightMM-c.Height)/c.NestedHeight))
+	}
+	return count
+}
+
+// Validate checks that the packing options are within their allowed ranges.
+// The returned error joins every problem found.
+func (o PackingOptions) Validate() error {
+	var errs []error
+	if o.SupportPercentage < 0 || o.SupportPercentage > 100 {
+		errs = append(errs, fmt.Errorf("support_percentage must be between 0 and 100, got %g", o.SupportPercentage))
+	}
+	if o.TargetUtilization < 0 || o.TargetUtilization > 100 {
+		errs = append(errs, fmt.Errorf("target_utilization must be between 0 and 100, got %g", o.TargetUtilization))
+	}
+	if o.MaxIterations < 0 {
+		errs = append(errs, fmt.Errorf("max_iterations must not be negative, got %d", o.MaxIterations))
+	}
+	return errors.Join(errs...)
+}
+
+// FootprintFitViolations returns the IDs of cartons that cannot be rotated
+// and whose length or width exceeds the pallet's MaxLength or MaxWidth. Such
+// cartons can never be packed, so the request is bound to fail. Cartons that
+// allow rotation are not checked.
+func (r *PackingRequest) FootprintFitViolations() []string {
+	var ids []string
+	for _, c := range r.Cartons {
+		if c.AllowRotation {
+			continue
+		}
+		if c.Length > r.PackingConstraints.MaxLength || c.Width > r.PackingConstraints.MaxWidth {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids
+}
+
+// SplitByWeight partitions the request's cartons into sub-requests whose
+// total carton weight is at most maxWeight grams, filling each greedily in
+// carton order. A carton line is split across sub-requests by Quantity when
+// it does not fit whole. A line whose single carton outweighs maxWeight is
+// put in a sub-request of its own, which the server will still reject. Every
+// sub-request keeps the original constraints and options. A maxWeight of
+// zero or less returns a single copy of the request.
+func (r *PackingRequest) SplitByWeight(maxWeight float64) []*PackingRequest {
+	newRequest := func(cartons ...Carton) *PackingRequest {
+		return &PackingRequest{Cartons: cartons, PackingConstraints: r.PackingConstraints, PackingOptions: r.PackingOptions}
+	}
+	if maxWeight <= 0 {
+		return []*PackingRequest{newRequest(slices.Clone(r.Cartons)...)}
+	}
+
+	var requests, oversize []*PackingRequest
+	current := newRequest()
+	var weight float64
+	for _, c := range r.Cartons {
+		if c.Weight > maxWeight {
+			oversize = append(oversize, newRequest(c))
+			continue
+		}
+		for remaining := c.Quantity; remaining > 0; {
+			fit := remaining
+			if c.Weight > 0 {
+				fit = min(remaining, int(math.Floor((maxWeight-weight)/c.Weight)))
+			}
+			if fit == 0 {
+				requests = append(requests, current)
+				current, weight = newRequest(), 0
+				continue
+			}
+			part := c
+			part.Quantity = fit
+			current.Cartons = append(current.Cartons, part)
+			weight += float64(fit) * c.Weight
+			remaining -= fit
+		}
+	}
+	if len(current.Cartons) > 0 {
+		requests = append(requests, current)
+	}
+	return append(requests, oversize...)
+}
+
+// InfeasibleCartons returns the IDs of cartons that can never be packed on
+// the request's pallet: those too heavy for MaxWeight on their own, or too
+// large for MaxLength, MaxWidth and MaxHeight in every orientation they may
+// be placed in (see Orientations).
+func (r *PackingRequest) InfeasibleCartons() []string {
+	var ids []string
+	for _, c := range r.Cartons {
+		if c.Weight > r.PackingConstraints.MaxWeight || !c.fitsWithin(r.PackingConstraints) {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids
+}
+
+// fitsWithin reports whether the carton fits within the constraints'
+// dimensions in at least one of its orientations
+func (c Carton) fitsWithin(p PackingConstraints) bool {
+	for _, d := range c.Orientations() {
+		if d.Length <= p.MaxLength && d.Width <= p.MaxWidth && d.Height <= p.MaxHeight {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+package palletizer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPackingOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PackingOptions
+		wantErr bool
+	}{
+		{"unset", PackingOptions{}, false},
+		{"in range", PackingOptions{TargetUtilization: 90}, false},
+		{"negative", PackingOptions{TargetUtilization: -1}, true},
+		{"above 100", PackingOptions{TargetUtilization: 101}, true},
+		{"max iterations", PackingOptions{MaxIterations: 5000}, false},
+		{"negative max iterations", PackingOptions{MaxIterations: -1}, true},
+		{"support percentage", PackingOptions{SupportPercentage: 80}, false},
+		{"support percentage above 100", PackingOptions{SupportPercentage: 120}, true},
+		{"negative support percentage", PackingOptions{SupportPercentage: -5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCartonValidate(t *testing.T) {
+	valid := Carton{ID: "BOX001", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 1}
+	tests := []struct {
+		name    string
+		modify  func(*Carton)
+		wantErr string
+	}{
+		{"valid", func(c *Carton) {}, ""},
+		{"empty ID", func(c *Carton) { c.ID = "" }, "carton (no ID): id must not be empty"},
+		{"zero length", func(c *Carton) { c.Length = 0 }, "carton BOX001: length must be positive, got 0"},
+		{"negative width", func(c *Carton) { c.Width = -1 }, "carton BOX001: width must be positive, got -1"},
+		{"zero height", func(c *Carton) { c.Height = 0 }, "carton BOX001: height must be positive, got 0"},
+		{"zero weight", func(c *Carton) { c.Weight = 0 }, "carton BOX001: weight must be positive, got 0"},
+		{"zero quantity", func(c *Carton) { c.Quantity = 0 }, "carton BOX001: quantity must be at least 1, got 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carton := valid
+			tt.modify(&carton)
+			err := carton.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPackingRequestValidate(t *testing.T) {
+	request := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "GOOD", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 1},
+			{ID: "FLAT", Length: 400, Width: 300, Height: 0, Weight: 5000, Quantity: 1},
+			{ID: "NONE", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 0},
+		},
+		PackingConstraints: StandardPallet(),
+		PackingOptions:     PackingOptions{SupportPercentage: 80},
+	}
+	err := request.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	expected := "carton FLAT: height must be positive, got 0\ncarton NONE: quantity must be at least 1, got 0"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	request.Cartons = request.Cartons[:1]
+	if err := request.Validate(); err != nil {
+		t.Errorf("expected valid request, got %v", err)
+	}
+
+	request.PackingConstraints.MaxWeight = 0
+	request.PackingOptions.SupportPercentage = 150
+	err = request.Validate()
+	expected = "packing_constraints: max_weight must be positive, got 0\nsupport_percentage must be between 0 and 100, got 150"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestPackingRequestValidateUprightFit(t *testing.T) {
+	tall := Carton{ID: "TALL", Length: 300, Width: 300, Height: 1500, Weight: 5000, Quantity: 1, AllowRotation: true}
+	request := &PackingRequest{Cartons: []Carton{tall}, PackingConstraints: StandardPallet()}
+	if err := request.Validate(); err != nil {
+		t.Errorf("expected a carton that can be laid down to be valid, got %v", err)
+	}
+
+	request.Cartons[0].UprightOnly = true
+	err := request.Validate()
+	expected := "carton TALL: does not fit within the pallet dimensions in any allowed orientation"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestCartonValidateNesting(t *testing.T) {
+	tests := []struct {
+		name    string
+		carton  Carton
+		wantErr bool
+	}{
+		{"not nestable", Carton{Height: 100, NestedHeight: 200}, false},
+		{"nested within height", Carton{Height: 100, Nestable: true, NestedHeight: 20}, false},
+		{"nested above height", Carton{Height: 100, Nestable: true, NestedHeight: 120}, true},
+		{"negative nested height", Carton{Height: 100, Nestable: true, NestedHeight: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.carton.ID, tt.carton.Length, tt.carton.Width, tt.carton.Weight, tt.carton.Quantity = "A", 100, 100, 500, 1
+			if err := tt.carton.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVolume(t *testing.T) {
+	// BOX001 from the README: 24x18x16 inches, 30 of them
+	carton := Carton{ID: "BOX001", Length: 609.6, Width: 457.2, Height: 406.4, Weight: 18143.68, Quantity: 30}
+	const expected = 113267386.368
+	if got := carton.Volume(); math.Abs(got-expected) > 1e-3 {
+		t.Errorf("expected %f mm^3, got %f", expected, got)
+	}
+
+	request := &PackingRequest{Cartons: []Carton{carton, {ID: "BOX002", Length: 100, Width: 100, Height: 100, Quantity: 2}}}
+	if got := request.TotalVolume(); math.Abs(got-(expected*30+2000000)) > 1e-3 {
+		t.Errorf("expected %f mm^3, got %f", expected*30+2000000, got)
+	}
+	if got := (&PackingRequest{}).TotalVolume(); got != 0 {
+		t.Errorf("expected 0 for an empty request, got %f", got)
+	}
+}
+
+func TestDensityOutliers(t *testing.T) {
+	// Six ordinary cartons of about 0.2 g/cm^3 and one pallet-sized carton
+	// entered with a weight of 50 g
+	request := &PackingRequest{Cartons: []Carton{
+		{ID: "BOX001", Length: 400, Width: 300, Height: 200, Weight: 4800},
+		{ID: "BOX002", Length: 400, Width: 300, Height: 200, Weight: 5000},
+		{ID: "BOX003", Length: 300, Width: 200, Height: 200, Weight: 2400},
+		{ID: "BOX004", Length: 300, Width: 200, Height: 200, Weight: 2500},
+		{ID: "BOX005", Length: 600, Width: 400, Height: 300, Weight: 14000},
+		{ID: "BOX006", Length: 600, Width: 400, Height: 300, Weight: 15000},
+		{ID: "TYPO", Length: 1200, Width: 800, Height: 1000, Weight: 50},
+		{ID: "NOSIZE", Weight: 1000},
+	}}
+
+	outliers := request.DensityOutliers(2)
+	if len(outliers) != 1 || outliers[0] != "TYPO" {
+		t.Errorf("expected [TYPO], got %v", outliers)
+	}
+
+	uniform := &PackingRequest{Cartons: []Carton{
+		{ID: "A", Length: 100, Width: 100, Height: 100, Weight: 200},
+		{ID: "B", Length: 200, Width: 100, Height: 100, Weight: 400},
+	}}
+	if got := uniform.DensityOutliers(0); got != nil {
+		t.Errorf("expected no outliers for identical densities, got %v", got)
+	}
+}
+
+func TestFootprintFitViolations(t *testing.T) {
+	request := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "FITS", Length: 1000, Width: 1200},
+			{ID: "TOO_LONG", Length: 1100, Width: 500},
+			{ID: "TOO_WIDE", Length: 500, Width: 1300},
+			{ID: "ROTATES", Length: 1200, Width: 1000, AllowRotation: true},
+		},
+		PackingConstraints: StandardPallet4048(),
+	}
+
+	violations := request.FootprintFitViolations()
+	if len(violations) != 2 || violations[0] != "TOO_LONG" || violations[1] != "TOO_WIDE" {
+		t.Errorf("expected [TOO_LONG TOO_WIDE], got %v", violations)
+	}
+}
+
+func TestCartonOrientations(t *testing.T) {
+	tests := []struct {
+		name     string
+		carton   Carton
+		expected int
+	}{
+		{"no rotation", Carton{Length: 300, Width: 200, Height: 100}, 1},
+		{"upright only", Carton{Length: 300, Width: 200, Height: 100, AllowRotation: true, UprightOnly: true}, 2},
+		{"free rotation", Carton{Length: 300, Width: 200, Height: 100, AllowRotation: true}, 6},
+		{"cube", Carton{Length: 100, Width: 100, Height: 100, AllowRotation: true}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orientations := tt.carton.Orientations()
+			if len(orientations) != tt.expected {
+				t.Fatalf("expected %d orientations, got %d: %v", tt.expected, len(orientations), orientations)
+			}
+			if tt.carton.UprightOnly {
+				for _, o := range orientations {
+					if o.Height != tt.carton.Height {
+						t.Errorf("upright carton tilted: %v", o)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCartonsUnderHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		carton   Carton
+		expected int
+	}{
+		{"fixed", Carton{Length: 400, Width: 300, Height: 250}, 4},
+		{"rotated onto smallest side", Carton{Length: 400, Width: 150, Height: 250, AllowRotation: true}, 6},
+		{"upright only", Carton{Length: 400, Width: 100, Height: 250, AllowRotation: true, UprightOnly: true}, 4},
+		{"nested", Carton{Length: 400, Width: 300, Height: 250, Nestable: true, NestedHeight: 50}, 16},
+		{"too tall", Carton{Length: 400, Width: 300, Height: 1200}, 0},
+		{"no dimensions", Carton{}, 0},
+	}
+	for _, tt := range tests {
+		if got := CartonsUnderHeight(tt.carton, 1000); got != tt.expected {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestSplitByWeight(t *testing.T) {
+	request := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "A", Weight: 300, Quantity: 5},
+			{ID: "B", Weight: 400, Quantity: 1},
+			{ID: "HEAVY", Weight: 1500, Quantity: 2},
+		},
+		PackingConstraints: StandardPallet(),
+	}
+
+	parts := request.SplitByWeight(1000)
+	var got [][]string
+	for _, part := range parts {
+		var lines []string
+		var weight float64
+		for _, c := range part.Cartons {
+			lines = append(lines, fmt.Sprintf("%sx%d", c.ID, c.Quantity))
+			weight += c.Weight * float64(c.Quantity)
+		}
+		if weight > 1000 && part.Cartons[0].ID != "HEAVY" {
+			t.Errorf("sub-request %v weighs %g, over the cap", lines, weight)
+		}
+		if part.PackingConstraints != StandardPallet() {
+			t.Error("expected constraints to be copied")
+		}
+		got = append(got, lines)
+	}
+	// 3xA fills 900 g with no room for a 4th; the remaining 2xA and B make
+	// exactly 1000 g, which is allowed.
+	expected := "[[Ax3] [Ax2 Bx1] [HEAVYx2]]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("expected %s, got %v", expected, got)
+	}
+
+	if parts := request.SplitByWeight(0); len(parts) != 1 || len(parts[0].Cartons) != 3 {
+		t.Errorf("expected a single copy for a zero cap, got %d parts", len(parts))
+	}
+}
+
+func TestInfeasibleCartons(t *testing.T) {
+	request := &PackingRequest{
+		Cartons: []Carton{
+			{ID: "FITS", Length: 1000, Width: 800, Height: 500, Weight: 1000},
+			{ID: "TALL_FIXED", Length: 500, Width: 500, Height: 1600, Weight: 1000},
+			{ID: "TALL_ROTATES", Length: 500, Width: 500, Height: 1600, Weight: 1000, AllowRotation: true},
+			{ID: "TALL_UPRIGHT", Length: 500, Width: 500, Height: 1600, Weight: 1000, AllowRotation: true, UprightOnly: true},
+			{ID: "SWAPPED", Length: 800, Width: 1900, Height: 500, Weight: 1000, AllowRotation: true, UprightOnly: true},
+			{ID: "HEAVY", Length: 100, Width: 100, Height: 100, Weight: 1000001},
+		},
+		PackingConstraints: PackingConstraints{MaxLength: 2000, MaxWidth: 800, MaxHeight: 1500, MaxWeight: 1000000},
+	}
+
+	// The tall cartons only fit lying down, which UprightOnly forbids;
+	// SWAPPED fits once its length and width are swapped.
+	got := request.InfeasibleCartons()
+	expected := []string{"TALL_FIXED", "TALL_UPRIGHT", "HEAVY"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
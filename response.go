@@ -0,0 +1,263 @@
+package palletizer
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// WeightSpread returns the minimum, maximum and population standard deviation
+// of the pallets' total weights. All values are zero when there are no pallets.
+func (r *PackingResponse) WeightSpread() (min, max, stddev float64) {
+	if len(r.Pallets) == 0 {
+		return 0, 0, 0
+	}
+
+	min, max = r.Pallets[0].TotalWeight, r.Pallets[0].TotalWeight
+	var sum float64
+	for _, p := range r.Pallets {
+		min = math.Min(min, p.TotalWeight)
+		max = math.Max(max, p.TotalWeight)
+		sum += p.TotalWeight
+	}
+	mean := sum / float64(len(r.Pallets))
+
+	var variance float64
+	for _, p := range r.Pallets {
+		variance += (p.TotalWeight - mean) * (p.TotalWeight - mean)
+	}
+	stddev = math.Sqrt(variance / float64(len(r.Pallets)))
+	return min, max, stddev
+}
+
+// ToImperial returns a copy of the response with all lengths converted from
+// millimeters to inches and all weights from grams to pounds. Carton
+// positions, dimensions and each pallet's center of gravity are converted
+// too. The receiver is left unchanged.
+func (r *PackingResponse) ToImperial() *PackingResponse {
+	if r == nil {
+		return nil
+	}
+
+	point := func(p Point3D) Point3D {
+		return Point3D{X: MMToInches(p.X), Y: MMToInches(p.Y), Z: MMToInches(p.Z)}
+	}
+
+	out := *r
+	out.Warnings = slices.Clone(r.Warnings)
+	out.Summary.Timings = maps.Clone(r.Summary.Timings)
+	out.Pallets = make([]Pallet, len(r.Pallets))
+	for i, p := range r.Pallets {
+		p.TotalWeight = GramsToPounds(p.TotalWeight)
+		p.TotalHeight = MMToInches(p.TotalHeight)
+		p.CenterOfGravity = point(p.CenterOfGravity)
+
+		cartons := make([]PlacedCarton, len(p.Cartons))
+		for j, c := range p.Cartons {
+			c.Position = point(c.Position)
+			c.Dimensions = Dimensions{
+				Length: MMToInches(c.Dimensions.Length),
+				Width:  MMToInches(c.Dimensions.Width),
+				Height: MMToInches(c.Dimensions.Height),
+			}
+			c.Weight = GramsToPounds(c.Weight)
+			cartons[j] = c
+		}
+		p.Cartons = cartons
+		out.Pallets[i] = p
+	}
+	return &out
+}
+
+// HasWarnings reports whether the server attached any warnings to an
+// otherwise successful response
+func (r *PackingResponse) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// LayerComplianceViolations returns the IDs of pallets with more than max
+// layers
+func (r *PackingResponse) LayerComplianceViolations(max int) []int {
+	var ids []int
+	for _, p := range r.Pallets {
+		if p.LayerCount() > max {
+			ids = append(ids, p.PalletID)
+		}
+	}
+	return ids
+}
+
+// CombinablePallets returns pairs of pallet IDs whose combined weight and
+// carton volume would fit within a single pallet of constraints c. It is a
+// heuristic based on totals only, not a re-solve: a reported pair is a
+// candidate for re-packing, not a guarantee that one pallet suffices.
+func (r *PackingResponse) CombinablePallets(c PackingConstraints) [][2]int {
+	capacity := c.MaxLength * c.MaxWidth * c.MaxHeight
+	var pairs [][2]int
+	for i := 0; i < len(r.Pallets); i++ {
+		for j := i + 1; j < len(r.Pallets); j++ {
+			a, b := r.Pallets[i], r.Pallets[j]
+			if a.TotalWeight+b.TotalWeight <= c.MaxWeight && a.loadVolume()+b.loadVolume() <= capacity {
+				pairs = append(pairs, [2]int{a.PalletID, b.PalletID})
+			}
+		}
+	}
+	return pairs
+}
+
+// SKULayerSpread returns, for each SKU, the number of distinct layers it
+// occupies across all pallets. Layers on different pallets count separately.
+// SKUs are recovered from placed carton IDs by removing the "_<n>" suffix.
+func (r *PackingResponse) SKULayerSpread() map[string]int {
+	type palletLayer struct{ pallet, layer int }
+	layers := make(map[string]map[palletLayer]bool)
+	for _, p := range r.Pallets {
+		for _, c := range p.Cartons {
+			sku := skuOf(c.CartonID)
+			if layers[sku] == nil {
+				layers[sku] = make(map[palletLayer]bool)
+			}
+			layers[sku][palletLayer{p.PalletID, c.Layer}] = true
+		}
+	}
+
+	spread := make(map[string]int, len(layers))
+	for sku, set := range layers {
+		spread[sku] = len(set)
+	}
+	return spread
+}
+
+// DistinctSKUsPerPallet returns, keyed by pallet ID, the number of distinct
+// SKUs on each pallet. SKUs are recovered from placed carton IDs by removing
+// the "_<n>" suffix.
+func (r *PackingResponse) DistinctSKUsPerPallet() map[int]int {
+	counts := make(map[int]int, len(r.Pallets))
+	for _, p := range r.Pallets {
+		skus := make(map[string]bool)
+		for _, c := range p.Cartons {
+			skus[skuOf(c.CartonID)] = true
+		}
+		counts[p.PalletID] = len(skus)
+	}
+	return counts
+}
+
+// SolveTime returns the time the server spent solving, from the "solve"
+// entry of Timings. Without a timing breakdown it falls back to the whole
+// ComputationTimeMs.
+func (s PackingSummary) SolveTime() time.Duration {
+	ms, ok := s.Timings["solve"]
+	if !ok {
+		ms = s.ComputationTimeMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// AggregateSummaries combines the summaries of several responses into one.
+// Pallet and carton counts, computation times and per-phase Timings are
+// summed, and average utilization is weighted by each response's packed
+// carton count. Nil responses are skipped.
+func AggregateSummaries(resps []*PackingResponse) PackingSummary {
+	var total PackingSummary
+	var weightedUtil float64
+	for _, r := range resps {
+		if r == nil {
+			continue
+		}
+		total.TotalPallets += r.Summary.TotalPallets
+		total.TotalCartonsPacked += r.Summary.TotalCartonsPacked
+		total.ComputationTimeMs += r.Summary.ComputationTimeMs
+		for phase, ms := range r.Summary.Timings {
+			if total.Timings == nil {
+				total.Timings = make(map[string]int)
+			}
+			total.Timings[phase] += ms
+		}
+		weightedUtil += r.Summary.AverageUtilization * float64(r.Summary.TotalCartonsPacked)
+	}
+	if total.TotalCartonsPacked > 0 {
+		total.AverageUtilization = weightedUtil / float64(total.TotalCartonsPacked)
+	}
+	return total
+}
+
+// UtilizationGap returns how many percentage points the response's average
+// utilization falls short of target, such as the request's
+// PackingOptions.TargetUtilization. It is negative when the target was
+// exceeded.
+func (r *PackingResponse) UtilizationGap(target float64) float64 {
+	return target - r.Summary.AverageUtilization
+}
+
+// PalletCountHistogram counts the responses by the number of pallets each
+// produced, mapping a pallet count to how many responses used that many
+// pallets. Nil responses are skipped.
+func PalletCountHistogram(resps []*PackingResponse) map[int]int {
+	histogram := make(map[int]int)
+	for _, r := range resps {
+		if r == nil {
+			continue
+		}
+		histogram[len(r.Pallets)]++
+	}
+	return histogram
+}
+
+// SKUMixViolations returns, in ascending order, the IDs of pallets holding
+// more than maxSKUs distinct SKUs
+func (r *PackingResponse) SKUMixViolations(maxSKUs int) []int {
+	var ids []int
+	for id, count := range r.DistinctSKUsPerPallet() {
+		if count > maxSKUs {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// DensestPallet returns the pallet with the highest Density, preferring the
+// lowest pallet ID on ties. It returns nil when there are no pallets.
+func (r *PackingResponse) DensestPallet() *Pallet {
+	var densest *Pallet
+	for i := range r.Pallets {
+		p := &r.Pallets[i]
+		if densest == nil || p.Density() > densest.Density() ||
+			(p.Density() == densest.Density() && p.PalletID < densest.PalletID) {
+			densest = p
+		}
+	}
+	return densest
+}
+
+// EstimateInductionSeconds returns how long a conveyor inducting
+// cartonsPerMinute takes to process every placed carton in the response. It
+// returns 0 if cartonsPerMinute is not positive.
+func (r *PackingResponse) EstimateInductionSeconds(cartonsPerMinute float64) float64 {
+	if cartonsPerMinute <= 0 {
+		return 0
+	}
+	var n int
+	for _, p := range r.Pallets {
+		n += len(p.Cartons)
+	}
+	return float64(n) / cartonsPerMinute * 60
+}
+
+// TotalFootprintArea returns the sum of every pallet's Footprint area in
+// square millimeters. Multiplied by dwell time it gives the floor-space
+// hours needed to stage the load. It returns 0 for a nil or empty response.
+func (r *PackingResponse) TotalFootprintArea() float64 {
+	if r == nil {
+		return 0
+	}
+	var area float64
+	for _, p := range r.Pallets {
+		length, width := p.Footprint()
+		area += length * width
+	}
+	return area
+}
@@ -0,0 +1,275 @@
+package palletizer
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeightSpread(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{{TotalWeight: 2000}, {TotalWeight: 4000}, {TotalWeight: 4000}, {TotalWeight: 6000}},
+	}
+
+	min, max, stddev := response.WeightSpread()
+	if min != 2000 || max != 6000 {
+		t.Errorf("expected min 2000 max 6000, got %f %f", min, max)
+	}
+	if stddev < 1414.2 || stddev > 1414.3 {
+		t.Errorf("expected stddev ~1414.21, got %f", stddev)
+	}
+
+	if min, max, stddev := (&PackingResponse{}).WeightSpread(); min != 0 || max != 0 || stddev != 0 {
+		t.Errorf("expected zeros for empty response, got %f %f %f", min, max, stddev)
+	}
+}
+
+func TestToImperial(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{
+				PalletID:        1,
+				TotalWeight:     18143.68,
+				TotalHeight:     406.4,
+				CenterOfGravity: Point3D{X: 304.8, Y: 228.6, Z: 203.2},
+				Cartons: []PlacedCarton{
+					{
+						CartonID:   "BOX001_1",
+						Position:   Point3D{X: 25.4, Y: 0, Z: 0},
+						Dimensions: Dimensions{Length: 609.6, Width: 457.2, Height: 406.4},
+						Weight:     18143.68,
+					},
+				},
+			},
+		},
+	}
+
+	imperial := response.ToImperial()
+	near := func(got, want float64) bool { return got > want-0.01 && got < want+0.01 }
+
+	pallet := imperial.Pallets[0]
+	if !near(pallet.TotalWeight, 40) || !near(pallet.TotalHeight, 16) {
+		t.Errorf("unexpected pallet totals: %f lbs, %f in", pallet.TotalWeight, pallet.TotalHeight)
+	}
+	if !near(pallet.CenterOfGravity.X, 12) || !near(pallet.CenterOfGravity.Y, 9) || !near(pallet.CenterOfGravity.Z, 8) {
+		t.Errorf("unexpected center of gravity: %v", pallet.CenterOfGravity)
+	}
+	carton := pallet.Cartons[0]
+	if !near(carton.Position.X, 1) || !near(carton.Dimensions.Length, 24) || !near(carton.Dimensions.Width, 18) || !near(carton.Weight, 40) {
+		t.Errorf("unexpected carton: %+v", carton)
+	}
+
+	if response.Pallets[0].Cartons[0].Dimensions.Length != 609.6 {
+		t.Error("expected original response to be unchanged")
+	}
+}
+
+func TestHasWarnings(t *testing.T) {
+	var response PackingResponse
+	if err := json.Unmarshal([]byte(`{"pallets":[],"warnings":["support relaxed for 2 cartons"]}`), &response); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if !response.HasWarnings() || response.Warnings[0] != "support relaxed for 2 cartons" {
+		t.Errorf("expected warning to be decoded, got %v", response.Warnings)
+	}
+	if (&PackingResponse{}).HasWarnings() {
+		t.Error("expected no warnings on an empty response")
+	}
+}
+
+func TestLayerComplianceViolations(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{PalletID: 1, Cartons: []PlacedCarton{{Layer: 0}, {Layer: 1}}},
+			{PalletID: 2, Cartons: []PlacedCarton{{Layer: 0}, {Layer: 1}, {Layer: 2}}},
+			{PalletID: 3},
+		},
+	}
+
+	violations := response.LayerComplianceViolations(2)
+	if len(violations) != 1 || violations[0] != 2 {
+		t.Errorf("expected violations [2], got %v", violations)
+	}
+}
+
+func TestCombinablePallets(t *testing.T) {
+	box := func(size float64) []PlacedCarton {
+		return []PlacedCarton{{Dimensions: Dimensions{Length: size, Width: size, Height: size}}}
+	}
+	constraints := PackingConstraints{MaxLength: 1000, MaxWidth: 1000, MaxHeight: 1000, MaxWeight: 10000}
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{PalletID: 1, TotalWeight: 3000, Cartons: box(500)},
+			{PalletID: 2, TotalWeight: 4000, Cartons: box(500)},
+			{PalletID: 3, TotalWeight: 8000, Cartons: box(500)},
+			{PalletID: 4, TotalWeight: 1000, Cartons: box(1000)},
+		},
+	}
+
+	pairs := response.CombinablePallets(constraints)
+	if len(pairs) != 1 || pairs[0] != [2]int{1, 2} {
+		t.Errorf("expected pairs [[1 2]], got %v", pairs)
+	}
+}
+
+func TestSKULayerSpread(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{PalletID: 1, Cartons: []PlacedCarton{
+				{CartonID: "A_1", Layer: 0}, {CartonID: "A_2", Layer: 0}, {CartonID: "A_3", Layer: 2}, {CartonID: "B_1", Layer: 1},
+			}},
+			{PalletID: 2, Cartons: []PlacedCarton{
+				{CartonID: "A_4", Layer: 0},
+			}},
+		},
+	}
+
+	spread := response.SKULayerSpread()
+	if spread["A"] != 3 || spread["B"] != 1 || len(spread) != 2 {
+		t.Errorf("expected map[A:3 B:1], got %v", spread)
+	}
+}
+
+func TestDistinctSKUsPerPallet(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{PalletID: 1, Cartons: []PlacedCarton{{CartonID: "A_1"}, {CartonID: "A_2"}, {CartonID: "B_1"}}},
+			{PalletID: 2, Cartons: []PlacedCarton{{CartonID: "C_1"}}},
+			{PalletID: 3},
+		},
+	}
+
+	counts := response.DistinctSKUsPerPallet()
+	if counts[1] != 2 || counts[2] != 1 || counts[3] != 0 || len(counts) != 3 {
+		t.Errorf("expected map[1:2 2:1 3:0], got %v", counts)
+	}
+}
+
+func TestAggregateSummaries(t *testing.T) {
+	resps := []*PackingResponse{
+		{Summary: PackingSummary{TotalPallets: 2, TotalCartonsPacked: 30, AverageUtilization: 90, ComputationTimeMs: 10, Timings: map[string]int{"parse": 2, "solve": 8}}},
+		nil,
+		{Summary: PackingSummary{TotalPallets: 1, TotalCartonsPacked: 10, AverageUtilization: 70, ComputationTimeMs: 5}},
+	}
+
+	summary := AggregateSummaries(resps)
+	expected := PackingSummary{TotalPallets: 3, TotalCartonsPacked: 40, AverageUtilization: 85, ComputationTimeMs: 15, Timings: map[string]int{"parse": 2, "solve": 8}}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("expected %+v, got %+v", expected, summary)
+	}
+}
+
+func TestSolveTime(t *testing.T) {
+	var summary PackingSummary
+	if err := json.Unmarshal([]byte(`{"computation_time_ms":120,"timings":{"parse":15,"solve":95,"serialize":10}}`), &summary); err != nil {
+		t.Fatalf("failed to decode summary: %v", err)
+	}
+	if summary.Timings["parse"] != 15 || len(summary.Timings) != 3 {
+		t.Errorf("unexpected timings: %v", summary.Timings)
+	}
+	if got := summary.SolveTime(); got != 95*time.Millisecond {
+		t.Errorf("expected solve time 95ms, got %v", got)
+	}
+
+	legacy := PackingSummary{ComputationTimeMs: 120}
+	if got := legacy.SolveTime(); got != 120*time.Millisecond {
+		t.Errorf("expected fallback to computation time, got %v", got)
+	}
+	if out, _ := json.Marshal(legacy); strings.Contains(string(out), "timings") {
+		t.Errorf("expected timings to be omitted, got %s", out)
+	}
+}
+
+func TestUtilizationGap(t *testing.T) {
+	response := &PackingResponse{Summary: PackingSummary{AverageUtilization: 82.5}}
+	if got := response.UtilizationGap(90); got != 7.5 {
+		t.Errorf("expected gap 7.5, got %f", got)
+	}
+	if got := response.UtilizationGap(80); got != -2.5 {
+		t.Errorf("expected gap -2.5 when the target is exceeded, got %f", got)
+	}
+}
+
+func TestPalletCountHistogram(t *testing.T) {
+	one := &PackingResponse{Pallets: make([]Pallet, 1)}
+	two := &PackingResponse{Pallets: make([]Pallet, 2)}
+	histogram := PalletCountHistogram([]*PackingResponse{one, two, nil, one, {}})
+
+	expected := map[int]int{0: 1, 1: 2, 2: 1}
+	if !maps.Equal(histogram, expected) {
+		t.Errorf("expected %v, got %v", expected, histogram)
+	}
+	if got := PalletCountHistogram(nil); len(got) != 0 {
+		t.Errorf("expected empty histogram, got %v", got)
+	}
+}
+
+func TestSKUMixViolations(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{PalletID: 3, Cartons: []PlacedCarton{{CartonID: "A_1"}, {CartonID: "B_1"}, {CartonID: "C_1"}}},
+			{PalletID: 1, Cartons: []PlacedCarton{{CartonID: "A_2"}, {CartonID: "B_2"}, {CartonID: "C_2"}}},
+			{PalletID: 2, Cartons: []PlacedCarton{{CartonID: "A_3"}, {CartonID: "A_4"}}},
+		},
+	}
+
+	violations := response.SKUMixViolations(2)
+	if len(violations) != 2 || violations[0] != 1 || violations[1] != 3 {
+		t.Errorf("expected [1 3], got %v", violations)
+	}
+}
+
+func TestDensestPallet(t *testing.T) {
+	cartons := []PlacedCarton{{Dimensions: Dimensions{Length: 100, Width: 100, Height: 100}}}
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{PalletID: 3, TotalWeight: 2000, TotalHeight: 100, Cartons: cartons},
+			{PalletID: 1, TotalWeight: 1000, TotalHeight: 100, Cartons: cartons},
+			{PalletID: 2, TotalWeight: 2000, TotalHeight: 100, Cartons: cartons},
+		},
+	}
+
+	densest := response.DensestPallet()
+	if densest == nil || densest.PalletID != 2 {
+		t.Errorf("expected pallet 2, got %v", densest)
+	}
+	if densest := (&PackingResponse{}).DensestPallet(); densest != nil {
+		t.Errorf("expected nil for empty response, got %v", densest)
+	}
+}
+
+func TestEstimateInductionSeconds(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{Cartons: make([]PlacedCarton, 20)},
+			{Cartons: make([]PlacedCarton, 10)},
+		},
+	}
+
+	if got := response.EstimateInductionSeconds(60); got != 30 {
+		t.Errorf("expected 30 seconds, got %f", got)
+	}
+	if got := response.EstimateInductionSeconds(0); got != 0 {
+		t.Errorf("expected 0 for a zero rate, got %f", got)
+	}
+}
+
+func TestTotalFootprintArea(t *testing.T) {
+	response := &PackingResponse{
+		Pallets: []Pallet{
+			{Cartons: []PlacedCarton{{Dimensions: Dimensions{Length: 1000, Width: 800, Height: 500}}}},
+			{Cartons: []PlacedCarton{{Position: Point3D{X: 200}, Dimensions: Dimensions{Length: 300, Width: 500, Height: 500}}}},
+		},
+	}
+
+	if got := response.TotalFootprintArea(); got != 1050000 {
+		t.Errorf("expected 1050000 mm^2, got %f", got)
+	}
+	var empty *PackingResponse
+	if got := empty.TotalFootprintArea(); got != 0 {
+		t.Errorf("expected 0 for a nil response, got %f", got)
+	}
+}
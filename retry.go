@@ -0,0 +1,104 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxRetryDelay caps the exponential backoff between attempts
+const maxRetryDelay = 30 * time.Second
+
+// defaultRetryPolicy makes a single attempt per call
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: 1,
+	baseDelay:   500 * time.Millisecond,
+}
+
+// retryPolicy decides how many attempts a failed call gets and how long to
+// wait between them
+type retryPolicy struct {
+	maxAttempts     int // attempts for 502, 503 and 504 responses
+	baseDelay       time.Duration
+	statusAttempts  map[int]int // attempts by status code, overriding maxAttempts
+	networkAttempts int         // attempts for network errors (0 = maxAttempts)
+}
+
+// maxAttemptsFor returns the total number of attempts allowed for a call
+// failing with err
+func (p retryPolicy) maxAttemptsFor(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if n, ok := p.statusAttempts[apiErr.StatusCode]; ok {
+			return n
+		}
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return p.maxAttempts
+		}
+		return 1
+	}
+
+	if isNetworkError(err) {
+		if p.networkAttempts > 0 {
+			return p.networkAttempts
+		}
+		return p.maxAttempts
+	}
+	return 1
+}
+
+// isNetworkError reports whether err is a failure to reach the server or
+// receive its response, as opposed to an error response or a call cancelled
+// by its context
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// wait sleeps before the attempt following attempt, doubling the delay each
+// time and randomizing its upper half so that clients failing together do
+// not retry in lockstep. It returns false without waiting out the delay if
+// ctx is done first or its deadline would pass during the wait.
+func (p retryPolicy) wait(ctx context.Context, attempt int) bool {
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxRetryDelay)
+	if half := delay / 2; half > 0 {
+		delay = half + rand.N(half+1)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// RetryError is returned when a call fails after more than one attempt. Its
+// message is that of the final attempt's error, and both errors.Is and
+// errors.As see the final error before the earlier ones.
+type RetryError struct {
+	Last    error   // error from the final attempt
+	Earlier []error // errors from the preceding attempts, in order
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (after %d attempts)", e.Last, len(e.Earlier)+1)
+}
+
+func (e *RetryError) Unwrap() []error {
+	return append([]error{e.Last}, e.Earlier...)
+}
@@ -0,0 +1,134 @@
+package palletizer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetryPolicy(t *testing.T) {
+	var calls int32
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(map[int]int{http.StatusServiceUnavailable: 3, http.StatusTooManyRequests: 2}))
+	client.baseURL = server.URL
+	client.retry.baseDelay = time.Millisecond
+
+	tests := []struct {
+		status   int
+		expected int32
+	}{
+		{http.StatusServiceUnavailable, 3},
+		{http.StatusTooManyRequests, 2},
+		{http.StatusBadGateway, 1},
+		{http.StatusBadRequest, 1},
+	}
+	for _, tt := range tests {
+		status = tt.status
+		atomic.StoreInt32(&calls, 0)
+		if _, err := client.Pack(context.Background(), &PackingRequest{}); err == nil {
+			t.Errorf("status %d: expected error", tt.status)
+		}
+		if n := atomic.LoadInt32(&calls); n != tt.expected {
+			t.Errorf("status %d: expected %d attempts, got %d", tt.status, tt.expected, n)
+		}
+	}
+}
+
+func TestWithNetworkRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var attempts int32
+	client := NewClient(
+		WithNetworkRetries(3),
+		WithBeforeRequest(func(ctx context.Context, req *http.Request) error {
+			atomic.AddInt32(&attempts, 1)
+			return nil
+		}),
+	)
+	client.baseURL = url
+	client.retry.baseDelay = time.Millisecond
+
+	if _, err := client.Pack(context.Background(), &PackingRequest{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	var calls int32
+	failures := int32(2)
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= atomic.LoadInt32(&failures) {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"pallets":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetry(3, time.Millisecond))
+	client.baseURL = server.URL
+
+	if _, err := client.Pack(context.Background(), &PackingRequest{}); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	atomic.StoreInt32(&failures, 5)
+	_, err := client.Pack(context.Background(), &PackingRequest{})
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || len(retryErr.Earlier) != 2 {
+		t.Fatalf("expected RetryError with 2 earlier errors, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected final status error to be reachable, got %v", err)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	status = http.StatusBadRequest
+	if _, err := client.Pack(context.Background(), &PackingRequest{}); err == nil {
+		t.Error("expected error for 400")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a 400 not to be retried, got %d attempts", n)
+	}
+}
+
+func TestWithRetryHonorsDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetry(5, time.Hour))
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Pack(ctx, &PackingRequest{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected retry to give up before the deadline, took %v", elapsed)
+	}
+}
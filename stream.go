@@ -0,0 +1,134 @@
+package palletizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PackSummaryFirst sends a packing request and returns the summary as soon as
+// it has been received, along with a function that finishes reading the
+// response and returns it in full. When the server sends the summary before
+// the placements, totals are available before the pallets have downloaded;
+// otherwise the whole response is read first and split. The returned function
+// must be called to release the connection; it may be called more than once.
+// After-response hooks run once the headers arrive and see an empty body.
+// The call is made once: it is not retried under WithRetry, WithRetryPolicy
+// or WithNetworkRetries, and WithTracerProvider starts no span for it.
+func (c *Client) PackSummaryFirst(ctx context.Context, request *PackingRequest) (summary *PackingSummary, rest func() (*PackingResponse, error), err error) {
+	request = c.withDefaults(request)
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	start := time.Now()
+
+	var header http.Header
+	if id := c.newCorrelationID(); id != "" {
+		header = http.Header{correlationIDHeader: {id}}
+	}
+	resp, err := c.open(ctx, "POST", "/v1/pack", request, header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hookResp := *resp
+	hookResp.Body = http.NoBody
+	for _, hook := range c.afterResponse {
+		if err := hook(ctx, &hookResp, time.Since(start)); err != nil {
+			resp.Body.Close()
+			return nil, nil, fmt.Errorf("after response hook: %w", err)
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			err = fmt.Errorf("failed to read response: %w", err)
+		} else {
+			err = newAPIError(resp.StatusCode, respBody)
+		}
+		if len(c.logHooks) > 0 {
+			c.logResponseEvent(ctx, resp.Request, "/v1/pack", request, resp.StatusCode, respBody, time.Since(start), err)
+		}
+		return nil, nil, err
+	}
+	if len(c.logHooks) > 0 {
+		c.logResponseEvent(ctx, resp.Request, "/v1/pack", request, resp.StatusCode, nil, time.Since(start), nil)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	fields := make(map[string]json.RawMessage)
+	if err := decodeFieldsUntil(dec, fields, "summary"); err != nil {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	summary = &PackingSummary{}
+	if raw, ok := fields["summary"]; ok {
+		if err := json.Unmarshal(raw, summary); err != nil {
+			resp.Body.Close()
+			return nil, nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	rest = sync.OnceValues(func() (*PackingResponse, error) {
+		defer cancel()
+		defer resp.Body.Close()
+		if err := decodeFieldsUntil(dec, fields, ""); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(fields); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		var response PackingResponse
+		if err := json.Unmarshal(buf.Bytes(), &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return &response, nil
+	})
+	return summary, rest, nil
+}
+
+// decodeFieldsUntil reads members of the top-level JSON object from dec into
+// fields, stopping after the member named stop or at the end of the object.
+// It consumes the opening brace on first use.
+func decodeFieldsUntil(dec *json.Decoder, fields map[string]json.RawMessage, stop string) error {
+	if dec.InputOffset() == 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok != json.Delim('{') {
+			return fmt.Errorf("expected JSON object, got %v", tok)
+		}
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		fields[key] = raw
+		if key == stop {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package palletizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPackSummaryFirst(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"summary":{"total_pallets":2,"total_cartons_packed":3},`))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte(`"pallets":[{"pallet_id":1},{"pallet_id":2}]}`))
+	}))
+	defer server.Close()
+
+	client := NewWithEndpoint(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	summary, rest, err := client.PackSummaryFirst(ctx, &PackingRequest{})
+	if err != nil {
+		t.Fatalf("PackSummaryFirst failed: %v", err)
+	}
+	if summary.TotalPallets != 2 || summary.TotalCartonsPacked != 3 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	close(release)
+	response, err := rest()
+	if err != nil {
+		t.Fatalf("rest failed: %v", err)
+	}
+	if len(response.Pallets) != 2 || response.Summary.TotalPallets != 2 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+}
+
+func TestPackSummaryFirstFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pallets":[{"pallet_id":1}],"summary":{"total_pallets":1}}`))
+	}))
+	defer server.Close()
+
+	summary, rest, err := NewWithEndpoint(server.URL).PackSummaryFirst(context.Background(), &PackingRequest{})
+	if err != nil {
+		t.Fatalf("PackSummaryFirst failed: %v", err)
+	}
+	if summary.TotalPallets != 1 {
+		t.Errorf("expected 1 pallet in summary, got %d", summary.TotalPallets)
+	}
+	response, err := rest()
+	if err != nil {
+		t.Fatalf("rest failed: %v", err)
+	}
+	if len(response.Pallets) != 1 {
+		t.Errorf("expected 1 pallet, got %d", len(response.Pallets))
+	}
+}
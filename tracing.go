@@ -0,0 +1,59 @@
+package palletizer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the SDK as the instrumentation scope of its spans
+const tracerName = "github.com/palletizer-app/go-sdk"
+
+// WithTracerProvider wraps each Pack call in an OpenTelemetry span named
+// "palletizer.Pack", taken from a tracer of tp. The span records the carton
+// count and total carton weight of the request and the pallet count and
+// server computation time of the response, and is marked as errored if the
+// call fails. PackSummaryFirst is not traced. Only the OpenTelemetry API is
+// used; the application chooses and configures the SDK behind tp.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = tp.Tracer(tracerName, trace.WithInstrumentationVersion(Version))
+	}
+}
+
+// startPackSpan starts the span for a Pack call when tracing is enabled. It
+// returns the context carrying the span and a function that records the
+// outcome and ends the span.
+func (c *Client) startPackSpan(ctx context.Context, request *PackingRequest) (context.Context, func(*PackingResponse, error)) {
+	if c.tracer == nil {
+		return ctx, func(*PackingResponse, error) {}
+	}
+
+	var attrs []attribute.KeyValue
+	if request != nil {
+		weight := 0.0
+		for _, carton := range request.Cartons {
+			weight += carton.Weight * float64(carton.Quantity)
+		}
+		attrs = append(attrs,
+			attribute.Int("palletizer.carton_count", cartonCount(request.Cartons)),
+			attribute.Float64("palletizer.total_weight", weight),
+		)
+	}
+	ctx, span := c.tracer.Start(ctx, "palletizer.Pack", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	return ctx, func(response *PackingResponse, err error) {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		span.SetAttributes(
+			attribute.Int("palletizer.pallet_count", len(response.Pallets)),
+			attribute.Int("palletizer.computation_time_ms", response.Summary.ComputationTimeMs),
+		)
+	}
+}
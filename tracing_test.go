@@ -0,0 +1,110 @@
+package palletizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// stubTracerProvider records the spans started by its tracers
+type stubTracerProvider struct {
+	noop.TracerProvider
+	spans []*stubSpan
+}
+
+func (p *stubTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return stubTracer{provider: p}
+}
+
+type stubTracer struct {
+	noop.Tracer
+	provider *stubTracerProvider
+}
+
+func (t stubTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &stubSpan{name: name, attrs: map[attribute.Key]attribute.Value{}}
+	config := trace.NewSpanStartConfig(opts...)
+	for _, attr := range config.Attributes() {
+		span.attrs[attr.Key] = attr.Value
+	}
+	t.provider.spans = append(t.provider.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type stubSpan struct {
+	noop.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	ended  bool
+}
+
+func (s *stubSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *stubSpan) SetStatus(code codes.Code, description string) { s.status = code }
+func (s *stubSpan) End(options ...trace.SpanEndOption)            { s.ended = true }
+
+func TestWithTracerProvider(t *testing.T) {
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"pallets":[{"pallet_id":1},{"pallet_id":2}],"summary":{"computation_time_ms":42}}`))
+	}))
+	defer server.Close()
+
+	tp := &stubTracerProvider{}
+	client := NewClient(WithTracerProvider(tp))
+	client.baseURL = server.URL
+
+	request := &PackingRequest{Cartons: []Carton{{ID: "BOX001", Weight: 1000, Quantity: 3}}}
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	fail = true
+	if _, err := client.Pack(context.Background(), request); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(tp.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tp.spans))
+	}
+	ok := tp.spans[0]
+	if ok.name != "palletizer.Pack" || !ok.ended || ok.status == codes.Error {
+		t.Errorf("unexpected span: %+v", ok)
+	}
+	for key, want := range map[attribute.Key]attribute.Value{
+		"palletizer.carton_count":        attribute.IntValue(3),
+		"palletizer.total_weight":        attribute.Float64Value(3000),
+		"palletizer.pallet_count":        attribute.IntValue(2),
+		"palletizer.computation_time_ms": attribute.IntValue(42),
+	} {
+		if got := ok.attrs[key]; got != want {
+			t.Errorf("%s: expected %v, got %v", key, want.Emit(), got.Emit())
+		}
+	}
+	if failed := tp.spans[1]; failed.status != codes.Error || !failed.ended {
+		t.Errorf("expected failed call's span to be errored and ended, got %+v", failed)
+	}
+}
+
+func TestNoTracerProvider(t *testing.T) {
+	ctx := context.Background()
+	got, end := NewClient().startPackSpan(ctx, &PackingRequest{})
+	end(nil, nil)
+	if got != ctx {
+		t.Error("expected context to be unchanged without a tracer provider")
+	}
+}
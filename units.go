@@ -0,0 +1,91 @@
+package palletizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plausible ranges for normalized carton fields, used by NormalizeUnits
+const (
+	minPlausibleLength = 1.0       // millimeters
+	maxPlausibleLength = 5000.0    // millimeters
+	minPlausibleWeight = 1.0       // grams
+	maxPlausibleWeight = 1000000.0 // grams (one tonne)
+)
+
+// lengthUnits maps length unit names to millimeters per unit
+var lengthUnits = map[string]float64{
+	"mm": 1, "millimeter": 1, "millimeters": 1,
+	"cm": 10, "centimeter": 10, "centimeters": 10,
+	"m": 1000, "meter": 1000, "meters": 1000,
+	"in": 25.4, "inch": 25.4, "inches": 25.4,
+	"ft": 304.8, "foot": 304.8, "feet": 304.8,
+}
+
+// weightUnits maps weight unit names to grams per unit
+var weightUnits = map[string]float64{
+	"g": 1, "gram": 1, "grams": 1,
+	"kg": 1000, "kilogram": 1000, "kilograms": 1000,
+	"lb": 453.592, "lbs": 453.592, "pound": 453.592, "pounds": 453.592,
+	"oz": 28.3495, "ounce": 28.3495, "ounces": 28.3495,
+}
+
+// NormalizeUnits returns a copy of the carton with Length, Width and Height
+// converted from lengthUnit to millimeters and Weight from weightUnit to
+// grams, so a source can mix units such as millimeters with pounds. Units
+// are case-insensitive ("mm", "cm", "m", "in", "ft"; "g", "kg", "lb", "oz",
+// and their spelled-out names). The returned warnings name any unknown unit,
+// whose fields are left unconverted, and any converted value outside a
+// plausible range, which usually indicates the wrong unit was given.
+func (c Carton) NormalizeUnits(lengthUnit, weightUnit string) (Carton, []string) {
+	var warnings []string
+
+	if factor, ok := lengthUnits[strings.ToLower(strings.TrimSpace(lengthUnit))]; ok {
+		c.Length *= factor
+		c.Width *= factor
+		c.Height *= factor
+		if c.NestedHeight != 0 {
+			c.NestedHeight *= factor
+		}
+		for _, f := range []struct {
+			name  string
+			value float64
+		}{{"length", c.Length}, {"width", c.Width}, {"height", c.Height}} {
+			if f.value < minPlausibleLength || f.value > maxPlausibleLength {
+				warnings = append(warnings, fmt.Sprintf("carton %s: %s %g mm is outside the plausible range %g-%g mm",
+					c.ID, f.name, f.value, minPlausibleLength, maxPlausibleLength))
+			}
+		}
+	} else {
+		warnings = append(warnings, fmt.Sprintf("carton %s: unknown length unit %q, dimensions left unconverted", c.ID, lengthUnit))
+	}
+
+	if factor, ok := weightUnits[strings.ToLower(strings.TrimSpace(weightUnit))]; ok {
+		c.Weight *= factor
+		if c.MaxLoadBearing != 0 {
+			c.MaxLoadBearing *= factor
+		}
+		if c.Weight < minPlausibleWeight || c.Weight > maxPlausibleWeight {
+			warnings = append(warnings, fmt.Sprintf("carton %s: weight %g g is outside the plausible range %g-%g g",
+				c.ID, c.Weight, minPlausibleWeight, maxPlausibleWeight))
+		}
+	} else {
+		warnings = append(warnings, fmt.Sprintf("carton %s: unknown weight unit %q, weight left unconverted", c.ID, weightUnit))
+	}
+
+	return c, warnings
+}
+
+// NewCartonImperial returns a carton with its dimensions given in inches and
+// its weight in pounds, converted to the millimeters and grams the API uses.
+// Other fields, such as AllowRotation, are left at their zero values.
+func NewCartonImperial(id string, lengthIn, widthIn, heightIn, weightLb float64, qty int) Carton {
+	return NewCartonMetric(id, InchesToMM(lengthIn), InchesToMM(widthIn), InchesToMM(heightIn), PoundsToGrams(weightLb), qty)
+}
+
+// NewCartonMetric returns a carton with its dimensions given in millimeters
+// and its weight in grams. Other fields, such as AllowRotation, are left at
+// their zero values.
+func NewCartonMetric(id string, lengthMM, widthMM, heightMM, weightG float64, qty int) Carton {
+	return Carton{ID: id, Length: lengthMM, Width: widthMM, Height: heightMM, Weight: weightG, Quantity: qty}
+}
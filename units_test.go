@@ -0,0 +1,51 @@
+package palletizer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeUnits(t *testing.T) {
+	carton := Carton{ID: "BOX001", Length: 609.6, Width: 457.2, Height: 406.4, Weight: 40, Quantity: 1}
+
+	normalized, warnings := carton.NormalizeUnits("mm", "LB")
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+	if normalized.Length != 609.6 || normalized.Weight < 18143.6 || normalized.Weight > 18143.7 {
+		t.Errorf("unexpected normalized carton: %+v", normalized)
+	}
+
+	tiny := Carton{ID: "TINY", Length: 0.01, Width: 100, Height: 100, Weight: 2}
+	_, warnings = tiny.NormalizeUnits("mm", "kg")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "length 0.01 mm") {
+		t.Errorf("expected implausible length warning, got %v", warnings)
+	}
+
+	normalized, warnings = carton.NormalizeUnits("furlong", "g")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `unknown length unit "furlong"`) {
+		t.Errorf("expected unknown unit warning, got %v", warnings)
+	}
+	if normalized.Length != carton.Length {
+		t.Errorf("expected dimensions unconverted, got %f", normalized.Length)
+	}
+}
+
+func TestNewCarton(t *testing.T) {
+	expected := Carton{
+		ID:       "BOX001",
+		Length:   InchesToMM(24),
+		Width:    InchesToMM(18),
+		Height:   InchesToMM(16),
+		Weight:   PoundsToGrams(40),
+		Quantity: 30,
+	}
+	if got := NewCartonImperial("BOX001", 24, 18, 16, 40, 30); got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	metric := NewCartonMetric("BOX002", 400, 300, 200, 5000, 2)
+	if metric != (Carton{ID: "BOX002", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 2}) {
+		t.Errorf("unexpected metric carton: %+v", metric)
+	}
+}